@@ -4,11 +4,14 @@ package clauthz
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"strings"
+	"sync/atomic"
 
 	"github.com/crewlinker/clgo/clconfig"
+	"github.com/crewlinker/clgo/clhealth"
 	"github.com/open-policy-agent/opa/logging"
 	"github.com/open-policy-agent/opa/sdk"
 	"go.uber.org/fx"
@@ -24,6 +27,9 @@ type Config struct {
 	OPASystemID string `env:"OPA_SYSTEM_ID" envDefault:"auth"`
 }
 
+// ErrBundleNotActivated is returned by the health check until OPA has activated the policy bundle.
+var ErrBundleNotActivated = errors.New("clauthz: policy bundle is not activated")
+
 //go:embed opa.yml
 var cfg []byte
 
@@ -35,6 +41,8 @@ type Authz struct {
 	logs *zap.Logger
 	opa  *sdk.OPA
 	opaw *zapio.Writer
+
+	activated atomic.Bool
 }
 
 // NewAuthz inits the auth service.
@@ -64,9 +72,26 @@ func (a *Authz) Start(ctx context.Context) (err error) {
 		return fmt.Errorf("failed to init opa: %w", err)
 	}
 
+	// without a ready channel, OPA is only returned once the bundle has been activated
+	a.activated.Store(true)
+
+	return nil
+}
+
+// Check fails until the policy bundle is activated.
+func (a *Authz) Check(context.Context) error {
+	if !a.activated.Load() {
+		return ErrBundleNotActivated
+	}
+
 	return nil
 }
 
+// NewChecker registers the health check with clhealth.
+func NewChecker(a *Authz) clhealth.Checker {
+	return clhealth.Checker{Name: "opa", Check: a.Check}
+}
+
 // Stop the auth service.
 func (a *Authz) Stop(ctx context.Context) (err error) {
 	if err := a.opaw.Close(); err != nil {
@@ -120,6 +145,8 @@ func Provide() fx.Option {
 			fx.OnStart(func(ctx context.Context, a *Authz) error { return a.Start(ctx) }),
 			fx.OnStop(func(ctx context.Context, a *Authz) error { return a.Stop(ctx) }),
 		)),
+		// register the health check
+		clhealth.ProvideChecker(NewChecker),
 	)
 }
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/crewlinker/clgo/clauthz"
+	"github.com/crewlinker/clgo/clhealth"
 	"github.com/crewlinker/clgo/clzap"
 	"github.com/samber/lo"
 
@@ -81,3 +82,21 @@ var _ = Describe("authz (served)", func() {
 		Expect(autz.IsAuthorized(ctx, TestAuthzInput{IsAdmin: true})).To(BeTrue())
 	})
 })
+
+var _ = Describe("authz health", func() {
+	It("should not be ready until the bundle is activated", func(ctx context.Context) {
+		var autz *clauthz.Authz
+		var hlth *clhealth.Health
+		app := fx.New(fx.Populate(&autz, &hlth),
+			clauthz.TestProvide(clauthz.AllowAll()),
+			clhealth.Provide(),
+			clzap.TestProvide())
+		Expect(autz.Check(ctx)).To(MatchError(clauthz.ErrBundleNotActivated))
+
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+
+		Expect(autz.Check(ctx)).To(Succeed())
+		Expect(hlth.Ready(ctx).Checks).To(HaveKeyWithValue("opa", HaveField("Status", clhealth.StatusOK)))
+	})
+})
@@ -2,6 +2,8 @@
 package clbuildinfo
 
 import (
+	"path"
+	"runtime/debug"
 	"strings"
 
 	"github.com/crewlinker/clgo/clconfig"
@@ -10,23 +12,38 @@ import (
 )
 
 // Config configures this package.
-type Config struct{}
+type Config struct {
+	// Name of the service, it defaults to the last element of the main package's import path
+	Name string `env:"NAME"`
+}
 
 // Info provides build-time information to the rest of the application.
 type Info struct {
 	cfg Config
 
+	name    string
 	version string
 }
 
 // New initializes the build info component.
 func New(cfg Config, version string) Info {
+	name := cfg.Name
+	if bi, ok := debug.ReadBuildInfo(); ok && name == "" && bi.Path != "" {
+		name = path.Base(bi.Path)
+	}
+
 	return Info{
 		cfg:     cfg,
+		name:    name,
 		version: version,
 	}
 }
 
+// Name of the service.
+func (in Info) Name() string {
+	return in.name
+}
+
 // Version as determined at build time.
 func (in Info) Version() string {
 	return in.version
@@ -29,4 +29,9 @@ var _ = Describe("build info", func() {
 		Expect(info).ToNot(BeNil())
 		Expect(info.Version()).To(Equal("v0.0.0-test"))
 	})
+
+	It("should report name", func() {
+		Expect(info.Name()).To(Equal("clbuildinfo.test"))
+		Expect(clbuildinfo.New(clbuildinfo.Config{Name: "foo"}, "v1").Name()).To(Equal("foo"))
+	})
 })
@@ -0,0 +1,43 @@
+// Package clconnectredis provides the Redis backend of the clconnect rate limiter.
+package clconnectredis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crewlinker/clgo/clconnect"
+	"github.com/crewlinker/clgo/clredis"
+	"go.uber.org/fx"
+)
+
+// RateLimitBackend adapts the clredis.Limiter into a backend for the rate limiter, so the limits are shared by a
+// fleet of instances.
+type RateLimitBackend struct {
+	lim *clredis.Limiter
+}
+
+// NewRateLimitBackend inits the backend.
+func NewRateLimitBackend(lim *clredis.Limiter) *RateLimitBackend {
+	return &RateLimitBackend{lim: lim}
+}
+
+// Allow implements the clconnect.RateLimitBackend.
+func (b *RateLimitBackend) Allow(
+	ctx context.Context, key string, limit clconnect.Limit,
+) (*clconnect.LimitResult, error) {
+	res, err := b.lim.Allow(ctx, key, clredis.Limit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to allow: %w", err)
+	}
+
+	return (*clconnect.LimitResult)(res), nil
+}
+
+// ProvideRateLimiter provides the rate limiting interceptor with the Redis backend, it requires the clredis
+// dependencies. The rate limiter uses a clconnect.ClientIPFunc when it is provided.
+func ProvideRateLimiter() fx.Option {
+	return fx.Options(
+		fx.Provide(fx.Annotate(clconnect.NewRateLimiter, fx.ParamTags(``, ``, ``, `optional:"true"`))),
+		fx.Provide(fx.Annotate(NewRateLimitBackend, fx.As(new(clconnect.RateLimitBackend)))),
+	)
+}
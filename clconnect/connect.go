@@ -6,11 +6,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"connectrpc.com/validate"
 	"github.com/bufbuild/protovalidate-go"
 	"github.com/crewlinker/clgo/clconfig"
+	"github.com/crewlinker/clgo/clsentry"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -26,6 +28,24 @@ type Config struct {
 	// PublicRPCProcedures configures the ConnectRPC methods that are plublic. For these procedures a special
 	// "anonymous" session will be passed to other middleware.
 	PublicRPCProcedures map[string]bool `env:"PUBLIC_RPC_PROCEDURES"`
+
+	// RLSTenantSetting configures the run-time setting that the tenant pgx transacters set to the tenant id.
+	// Row-level security policies can read it with current_setting().
+	RLSTenantSetting string `env:"RLS_TENANT_SETTING" envDefault:"app.tenant_id"`
+	// RLSDefaultRole configures the role that is assumed at the start of the tx when the resolved tenant
+	// doesn't specify one. Policies are not enforced for table owners, so this should be a less privileged role.
+	// It is required for the tenant pgx transacters.
+	RLSDefaultRole string `env:"RLS_DEFAULT_ROLE"`
+
+	// RateLimits configures the number of requests per period for each procedure (and subject). The "*" procedure
+	// configures the limit for all procedures that are not configured explicitly.
+	RateLimits map[string]int `env:"RATE_LIMITS"`
+	// SubjectRateLimits configures the number of requests per period for specific subjects, for each procedure. It
+	// takes precedence over the procedure limits, and zero disables limiting the subject. Subjects are identified as
+	// "sub:<jwt subject>", "ory:<identity id>" or "ip:<client ip>", and configured as "ip:10.0.0.1=100,sub:svc=0".
+	SubjectRateLimits map[string]int `env:"SUBJECT_RATE_LIMITS" envKeyValSeparator:"="`
+	// RateLimitPeriod configures the period of the rate limits.
+	RateLimitPeriod time.Duration `env:"RATE_LIMIT_PERIOD" envDefault:"1m"`
 }
 
 // ROTransacter is an interceptor that add read-only transactions to the context.
@@ -69,11 +89,16 @@ func New[RO, RW any](
 	rwTx RWTransacter, // optional
 	joAuth *JWTOPAAuth, // optional
 	oryAuth *OryAuth, // optional
+	rateLimiter *RateLimiter, // optional
+	sentry *clsentry.Interceptor, // optional
 ) http.Handler {
 	mux := http.NewServeMux()
 
-	// base interceptors
+	// base interceptors, the error reporting comes first so it observes the result of the others
 	baseIntercepts := []connect.Interceptor{valr, logr}
+	if sentry != nil {
+		baseIntercepts = append([]connect.Interceptor{sentry}, baseIntercepts...)
+	}
 
 	// optional injectors, check for nil
 	{
@@ -84,15 +109,20 @@ func New[RO, RW any](
 		if oryAuth != nil {
 			baseIntercepts = append(baseIntercepts, oryAuth)
 		}
+
+		// the rate limiter comes after auth, so it can limit per subject
+		if rateLimiter != nil {
+			baseIntercepts = append(baseIntercepts, rateLimiter)
+		}
 	}
 
-	// base options
+	// base options, the recoverer is the outermost so the interceptors observe panics
 	interceptors := connect.WithInterceptors(baseIntercepts...)
 	recoverer := connect.WithRecover(rcvr.handle)
 
 	// setup read-write specific options (interceptors)
 	{
-		rwopts := []connect.HandlerOption{interceptors, recoverer}
+		rwopts := []connect.HandlerOption{recoverer, interceptors}
 		if rwTx != nil {
 			rwopts = append(rwopts, connect.WithInterceptors(rwTx))
 		}
@@ -103,7 +133,7 @@ func New[RO, RW any](
 
 	// setup read-only specific options (interceptors)
 	{
-		roopts := []connect.HandlerOption{interceptors, recoverer}
+		roopts := []connect.HandlerOption{recoverer, interceptors}
 		if roTx != nil {
 			roopts = append(roopts, connect.WithInterceptors(roTx))
 		}
@@ -140,7 +170,8 @@ func Provide[RO, RW any](name string) fx.Option {
 		fx.Provide(fx.Annotate(New[RO, RW],
 			// the transacters are optional, so we can use connect rpc without
 			fx.ParamTags(``, ``, ``, ``, ``, ``, ``, ``, ``,
-				`optional:"true"`, `optional:"true"`, `optional:"true"`, `optional:"true"`),
+				`optional:"true"`, `optional:"true"`, `optional:"true"`, `optional:"true"`, `optional:"true"`,
+				`optional:"true"`),
 			fx.ResultTags(`name:"`+name+`"`))),
 		// provide mandatory middleware constructors
 		fx.Provide(protovalidate.New, NewRecoverer, NewLogger),
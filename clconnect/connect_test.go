@@ -16,6 +16,8 @@ import (
 	"github.com/crewlinker/clgo/clconnect"
 	clconnectv1 "github.com/crewlinker/clgo/clconnect/v1"
 	"github.com/crewlinker/clgo/clconnect/v1/clconnectv1connect"
+	"github.com/crewlinker/clgo/clid"
+	clidv1 "github.com/crewlinker/clgo/clid/v1"
 	"github.com/crewlinker/clgo/clpostgres"
 	"github.com/crewlinker/clgo/clzap"
 	"github.com/joho/godotenv"
@@ -93,6 +95,20 @@ var _ = Describe("rpc", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should validate clids before the handler runs", func(ctx context.Context) {
+		_, err := roc.Foo(ctx, &connect.Request[clconnectv1.FooRequest]{
+			Msg: &clconnectv1.FooRequest{UserId: clid.New("user").Proto()},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, val := range []string{"orga-01HGWKKAWGABYZR1S1G9JMY5HZ", "user_01HGWKKAWGABYZR1S1G9JMY5HZ", "user-01HGWKKAWG"} {
+			_, err = roc.Foo(ctx, &connect.Request[clconnectv1.FooRequest]{
+				Msg: &clconnectv1.FooRequest{UserId: &clidv1.CLID{Value: val}},
+			})
+			Expect(connect.CodeOf(err)).To(Equal(connect.CodeInvalidArgument), val)
+		}
+	})
+
 	It("should serve not found", func(ctx context.Context) {
 		rec, req := httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/bogus", nil)
 		hdl.ServeHTTP(rec, req)
@@ -209,6 +225,14 @@ func ProvidePgx() fx.Option {
 	)
 }
 
+func ProvideTenantPgx() fx.Option {
+	return fx.Options(
+		Provide(),
+		clconnect.ProvideTenantPgxTransactors(),
+		fx.Provide(NewPgxReadOnly, NewPgxReadWrite),
+	)
+}
+
 func ProvideEnt() fx.Option {
 	return fx.Options(
 		Provide(),
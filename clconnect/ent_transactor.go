@@ -86,15 +86,23 @@ func txEntIntercept[TX EntModelTx, MC EntModelClient[TX]](
 		return nil, fmt.Errorf("failed to begin tx: %w", err)
 	}
 
+	var committed bool
+
+	hctx, hks := cltx.WithHooks(ctx)
+
 	defer func() {
+		if committed {
+			return
+		}
+
 		if rberr := tx.Rollback(); rberr != nil {
-			logs.Error("failed to rollback tx", zap.Error(err))
+			logs.Error("failed to rollback tx", zap.Error(rberr))
 		}
-	}()
 
-	ctx = cltx.WithTx(ctx, tx)
+		hks.RunAfterRollback(hctx)
+	}()
 
-	resp, err := next(ctx, req)
+	resp, err := next(cltx.WithTx(hctx, tx), req)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +111,9 @@ func txEntIntercept[TX EntModelTx, MC EntModelClient[TX]](
 		return nil, fmt.Errorf("failed to commit tx: %w", err)
 	}
 
+	committed = true
+	hks.RunAfterCommit(hctx)
+
 	return resp, nil
 }
 
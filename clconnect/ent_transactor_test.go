@@ -2,6 +2,7 @@ package clconnect_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -14,6 +15,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 )
 
@@ -53,6 +55,42 @@ var _ = Describe("ent", func() {
 	})
 })
 
+var _ = Describe("ent hooks", func() {
+	var rwtx *clconnect.EntRWTransactor[*modelTx, *modelClient]
+
+	BeforeEach(func() {
+		rwtx = clconnect.NewEntRWTransactor[*modelTx, *modelClient](zap.NewNop(), &modelClient{})
+	})
+
+	call := func(ctx context.Context, fail bool) (committed, rolledBack bool) {
+		_, err := rwtx.WrapUnary(func(ctx context.Context, ar connect.AnyRequest) (connect.AnyResponse, error) {
+			cltx.AfterCommit(ctx, func(context.Context) { committed = true })
+			cltx.AfterRollback(ctx, func(context.Context) { rolledBack = true })
+
+			if fail {
+				return nil, errors.New("fail")
+			}
+
+			return connect.NewResponse(&clconnectv1.CheckHealthResponse{}), nil
+		})(ctx, connect.NewRequest(&clconnectv1.CheckHealthRequest{}))
+		Expect(err != nil).To(Equal(fail))
+
+		return committed, rolledBack
+	}
+
+	It("should fire after-commit callbacks", func(ctx context.Context) {
+		committed, rolledBack := call(ctx, false)
+		Expect(committed).To(BeTrue())
+		Expect(rolledBack).To(BeFalse())
+	})
+
+	It("should fire after-rollback callbacks", func(ctx context.Context) {
+		committed, rolledBack := call(ctx, true)
+		Expect(committed).To(BeFalse())
+		Expect(rolledBack).To(BeTrue())
+	})
+})
+
 // test ent model Tx.
 type modelTx struct{}
 
@@ -43,7 +43,8 @@ func (l Logger) intercept(next connect.UnaryFunc) connect.UnaryFunc {
 			return resp, nil // nothing to do
 		}
 
-		clzap.Log(ctx, l.logs).Error("server error", zap.Error(err), zap.Stack("stack"))
+		// the error is returned, so it is reported by the (error reporting) interceptors that wrap this one
+		clzap.Log(ctx, l.logs).Error("server error", zap.Error(err), zap.Stack("stack"), clzap.Handled())
 
 		var cerr *connect.Error
 		if !errors.As(err, &cerr) {
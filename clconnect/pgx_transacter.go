@@ -19,6 +19,7 @@ type PgxROTransacter struct {
 	cfg  Config
 	logs *zap.Logger
 	ro   *pgxpool.Pool
+	tres TenantResolver
 	connect.Interceptor
 }
 
@@ -30,12 +31,27 @@ func NewPgxROTransacter(cfg Config, logs *zap.Logger, ro *pgxpool.Pool) *PgxROTr
 	return intr
 }
 
+// NewTenantPgxROTransacter inits a Transacter that scopes the tx to the resolved tenant, this requires
+// a default role to be configured.
+func NewTenantPgxROTransacter(
+	cfg Config, logs *zap.Logger, ro *pgxpool.Pool, tres TenantResolver,
+) (*PgxROTransacter, error) {
+	if err := checkTenantRole(cfg, tres); err != nil {
+		return nil, err
+	}
+
+	intr := NewPgxROTransacter(cfg, logs, ro)
+	intr.tres = tres
+
+	return intr, nil
+}
+
 func (l PgxROTransacter) intercept(next connect.UnaryFunc) connect.UnaryFunc {
 	return connect.UnaryFunc(func(
 		ctx context.Context,
 		req connect.AnyRequest,
 	) (connect.AnyResponse, error) {
-		return txPgxIntercept(ctx, l.logs, req, l.ro, next, pgx.TxOptions{
+		return txPgxIntercept(ctx, l.cfg, l.logs, l.tres, req, l.ro, next, pgx.TxOptions{
 			AccessMode: pgx.ReadOnly,
 		})
 	})
@@ -46,6 +62,7 @@ type PgxRWTransacter struct {
 	cfg  Config
 	logs *zap.Logger
 	rw   *pgxpool.Pool
+	tres TenantResolver
 	connect.Interceptor
 }
 
@@ -57,18 +74,35 @@ func NewPgxRWTransacter(cfg Config, logs *zap.Logger, rw *pgxpool.Pool) *PgxRWTr
 	return intr
 }
 
+// NewTenantPgxRWTransacter inits a Transacter that scopes the tx to the resolved tenant, this requires
+// a default role to be configured.
+func NewTenantPgxRWTransacter(
+	cfg Config, logs *zap.Logger, rw *pgxpool.Pool, tres TenantResolver,
+) (*PgxRWTransacter, error) {
+	if err := checkTenantRole(cfg, tres); err != nil {
+		return nil, err
+	}
+
+	intr := NewPgxRWTransacter(cfg, logs, rw)
+	intr.tres = tres
+
+	return intr, nil
+}
+
 func (l PgxRWTransacter) intercept(next connect.UnaryFunc) connect.UnaryFunc {
 	return connect.UnaryFunc(func(
 		ctx context.Context,
 		req connect.AnyRequest,
 	) (connect.AnyResponse, error) {
-		return txPgxIntercept(ctx, l.logs, req, l.rw, next, pgx.TxOptions{})
+		return txPgxIntercept(ctx, l.cfg, l.logs, l.tres, req, l.rw, next, pgx.TxOptions{})
 	})
 }
 
 func txPgxIntercept(
 	ctx context.Context,
+	cfg Config,
 	logs *zap.Logger,
+	tres TenantResolver,
 	req connect.AnyRequest,
 	db *pgxpool.Pool,
 	next connect.UnaryFunc,
@@ -81,24 +115,42 @@ func txPgxIntercept(
 		return nil, fmt.Errorf("failed to begin tx: %w", err)
 	}
 
+	var committed bool
+
+	hctx, hks := cltx.WithHooks(ctx)
+
 	defer func() {
 		if rberr := tx.Rollback(ctx); rberr != nil && !errors.Is(rberr, pgx.ErrTxClosed) {
 			logs.Error("failed to rollback tx", zap.Error(rberr))
 		}
+
+		if !committed {
+			hks.RunAfterRollback(hctx)
+		}
 	}()
 
-	ctx = cltx.WithPgx(ctx, tx)
+	if tres != nil {
+		if err := scopeTenant(ctx, cfg, tres, tx); err != nil {
+			return nil, connect.NewError(connect.CodePermissionDenied, err)
+		}
+	}
 
-	resp, err := next(ctx, req)
+	resp, err := next(cltx.WithPgx(hctx, tx), req)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := tx.Commit(ctx); err != nil &&
-		!errors.Is(err, pgx.ErrTxCommitRollback) { // is rolled back, that is fine
-		return nil, fmt.Errorf("failed to commit tx: %w", err)
+	if err := tx.Commit(ctx); err != nil {
+		if !errors.Is(err, pgx.ErrTxCommitRollback) {
+			return nil, fmt.Errorf("failed to commit tx: %w", err)
+		}
+
+		return resp, nil // is rolled back, that is fine
 	}
 
+	committed = true
+	hks.RunAfterCommit(hctx)
+
 	return resp, nil
 }
 
@@ -114,3 +166,17 @@ func ProvidePgxTransactors() fx.Option {
 			fx.ParamTags(``, ``, `name:"rw"`))),
 	)
 }
+
+// ProvideTenantPgxTransactors provides transactors for pgx transactions that are scoped to the tenant for
+// row-level security, it requires a TenantResolver to be provided.
+func ProvideTenantPgxTransactors() fx.Option {
+	return fx.Options(
+		// database transactors
+		fx.Provide(fx.Annotate(NewTenantPgxROTransacter,
+			fx.As(new(ROTransacter)),
+			fx.ParamTags(``, ``, `name:"ro"`))),
+		fx.Provide(fx.Annotate(NewTenantPgxRWTransacter,
+			fx.As(new(RWTransacter)),
+			fx.ParamTags(``, ``, `name:"rw"`))),
+	)
+}
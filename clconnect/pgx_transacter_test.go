@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 
 	"connectrpc.com/connect"
 	"github.com/crewlinker/clgo/clconnect"
@@ -13,6 +14,7 @@ import (
 	"github.com/crewlinker/clgo/clpostgres/cltx"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
 	"go.uber.org/fx"
 	"go.uber.org/zap/zaptest/observer"
 )
@@ -51,6 +53,47 @@ var _ = Describe("pgx", func() {
 	})
 })
 
+var _ = Describe("pgx tenant", func() {
+	var roc clconnectv1connect.ReadOnlyServiceClient
+	var tenantID string
+
+	tenantResolver := fx.Supply(fx.Annotate(clconnect.TenantResolverFunc(func(context.Context) (clconnect.Tenant, error) {
+		return clconnect.Tenant{ID: tenantID}, nil
+	}), fx.As(new(clconnect.TenantResolver))))
+
+	BeforeEach(func(ctx context.Context) {
+		tenantID = "tenant1"
+		os.Setenv("CLCONNECT_RLS_DEFAULT_ROLE", "pg_read_all_data")
+		DeferCleanup(os.Unsetenv, "CLCONNECT_RLS_DEFAULT_ROLE")
+
+		app := fx.New(fx.Populate(&roc), ProvideTenantPgx(), tenantResolver)
+
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should scope the tx to the tenant", func(ctx context.Context) {
+		resp, err := roc.Foo(ctx, &connect.Request[clconnectv1.FooRequest]{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Msg.GetBar()).To(Equal("tenant1"))
+	})
+
+	It("should reject a missing tenant", func(ctx context.Context) {
+		tenantID = ""
+
+		_, err := roc.Foo(ctx, &connect.Request[clconnectv1.FooRequest]{})
+		Expect(connect.CodeOf(err)).To(Equal(connect.CodePermissionDenied))
+		Expect(err).To(MatchError(ContainSubstring(clconnect.ErrNoTenant.Error())))
+	})
+
+	It("should require a default role", func() {
+		os.Unsetenv("CLCONNECT_RLS_DEFAULT_ROLE")
+
+		app := fx.New(fx.Populate(&roc), ProvideTenantPgx(), tenantResolver)
+		Expect(app.Err()).To(MatchError(clconnect.ErrNoRLSDefaultRole))
+	})
+})
+
 // pgxReadWrite represents the read-write side of the rpc.
 type pgxReadWrite struct{}
 
@@ -95,9 +138,17 @@ func (rw pgxReadOnly) Foo(
 	ctx context.Context, req *connect.Request[clconnectv1.FooRequest],
 ) (*connect.Response[clconnectv1.FooResponse], error) {
 	tx := cltx.Pgx(ctx)
+
+	var tenantID *string
+	if err := tx.QueryRow(ctx, `SELECT current_setting('app.tenant_id', true)`).Scan(&tenantID); err != nil {
+		return nil, fmt.Errorf("failed to query tenant setting: %w", err)
+	}
+
 	if _, err := tx.Exec(ctx, `UPDATE pg_catalog.pg_class SET relname = relname WHERE oid = -1;`); err == nil {
 		return nil, errors.New("should fail because read-only")
 	}
 
-	return &connect.Response[clconnectv1.FooResponse]{}, nil
+	return &connect.Response[clconnectv1.FooResponse]{
+		Msg: &clconnectv1.FooResponse{Bar: lo.FromPtr(tenantID)},
+	}, nil
 }
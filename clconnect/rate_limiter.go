@@ -0,0 +1,238 @@
+package clconnect
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/crewlinker/clgo/clory"
+	"github.com/crewlinker/clgo/clzap"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrRateLimited is returned when a request exceeds the rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// Limit describes a rate limit: a number of events per period, with a burst that may be used all at once.
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// LimitResult describes the result of a rate limited event.
+type LimitResult struct {
+	// Allowed is the number of events that were allowed, zero if the event was limited.
+	Allowed int
+	// Remaining is the number of events that may still happen right now.
+	Remaining int
+	// RetryAfter is the duration after which the event would be allowed, -1 if it was allowed.
+	RetryAfter time.Duration
+	// ResetAfter is the duration after which the limit is back at its full burst.
+	ResetAfter time.Duration
+}
+
+// RateLimitBackend keeps track of the rate limits. The clconnectredis package adapts the clredis.Limiter for a
+// fleet of instances.
+type RateLimitBackend interface {
+	Allow(ctx context.Context, key string, limit Limit) (*LimitResult, error)
+}
+
+// ClientIPFunc returns the ip of the client from the request context, e.g. clwebserver.ClientIP which takes
+// trusted proxies into account. It returns an invalid address when it is unknown.
+type ClientIPFunc func(ctx context.Context) netip.Addr
+
+// RateLimiter is an interceptor that limits the rate of requests per procedure and subject.
+type RateLimiter struct {
+	cfg      Config
+	logs     *zap.Logger
+	bend     RateLimitBackend
+	clientIP ClientIPFunc
+	connect.Interceptor
+}
+
+// NewRateLimiter inits the rate limiting interceptor. The client ip function is optional, without it the host of
+// the peer address is used to identify anonymous clients.
+func NewRateLimiter(cfg Config, logs *zap.Logger, bend RateLimitBackend, cip ClientIPFunc) *RateLimiter {
+	intr := &RateLimiter{cfg: cfg, logs: logs.Named("rate_limiter"), bend: bend, clientIP: cip}
+	intr.Interceptor = connect.UnaryInterceptorFunc(intr.intercept)
+
+	return intr
+}
+
+// limit returns the limit for the procedure and subject, and false if it is not limited. A limit that is
+// configured for the subject takes precedence over the limit of the procedure.
+func (l RateLimiter) limit(procedure, subject string) (Limit, bool) {
+	rate, ok := l.cfg.SubjectRateLimits[subject]
+	if !ok {
+		rate, ok = l.cfg.RateLimits[procedure]
+	}
+
+	if !ok {
+		rate, ok = l.cfg.RateLimits["*"]
+	}
+
+	if !ok || rate <= 0 {
+		return Limit{}, false
+	}
+
+	return Limit{Rate: rate, Period: l.cfg.RateLimitPeriod, Burst: rate}, true
+}
+
+// subject identifies who is doing the request: the JWT subject, the Ory identity or the client ip. The client ip
+// from the ClientIPFunc is preferred, it may take trusted proxies into account. Else it is the host of the peer
+// address, without the port that differs per connection.
+func (l RateLimiter) subject(ctx context.Context, peer connect.Peer) string {
+	if sub := IdentityFromContext(ctx).Subject(); sub != "" {
+		return "sub:" + sub
+	}
+
+	if sess := clory.Session(ctx); sess != nil && sess.Identity != nil {
+		return "ory:" + sess.Identity.Id
+	}
+
+	if l.clientIP != nil {
+		if ip := l.clientIP(ctx); ip.IsValid() {
+			return "ip:" + ip.String()
+		}
+	}
+
+	host, _, err := net.SplitHostPort(peer.Addr)
+	if err != nil {
+		host = peer.Addr
+	}
+
+	return "ip:" + host
+}
+
+// allow checks the rate limit of the procedure for the subject of the request, it returns a connect error when
+// the request exceeds it.
+func (l RateLimiter) allow(ctx context.Context, spec connect.Spec, peer connect.Peer) error {
+	sub := l.subject(ctx, peer)
+
+	limit, ok := l.limit(spec.Procedure, sub)
+	if !ok {
+		return nil
+	}
+
+	key := spec.Procedure + ":" + sub
+
+	res, err := l.bend.Allow(ctx, key, limit)
+	if err != nil {
+		// we rather serve the request than fail when the backend is unavailable
+		clzap.Log(ctx, l.logs).Error("failed to check rate limit, allowing", zap.String("key", key), zap.Error(err))
+
+		return nil
+	}
+
+	if res.Allowed > 0 {
+		return nil
+	}
+
+	cerr := connect.NewError(connect.CodeResourceExhausted, ErrRateLimited)
+	if detail, derr := connect.NewErrorDetail(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(res.RetryAfter),
+	}); derr == nil {
+		cerr.AddDetail(detail)
+	} else {
+		clzap.Log(ctx, l.logs).Error("failed to init error detail", zap.Error(derr))
+	}
+
+	return cerr
+}
+
+func (l RateLimiter) intercept(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(
+		ctx context.Context,
+		req connect.AnyRequest,
+	) (connect.AnyResponse, error) {
+		if err := l.allow(ctx, req.Spec(), req.Peer()); err != nil {
+			return nil, err
+		}
+
+		return next(ctx, req)
+	})
+}
+
+// WrapStreamingHandler limits the rate at which streams are started, the messages on a stream are not limited.
+func (l RateLimiter) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := l.allow(ctx, conn.Spec(), conn.Peer()); err != nil {
+			return err
+		}
+
+		return next(ctx, conn)
+	})
+}
+
+// MemoryRateLimitBackend keeps track of rate limits in memory, for single instance deployments. It implements the
+// same algorithm (GCRA) as the clredis.Limiter.
+type MemoryRateLimitBackend struct {
+	mu   sync.Mutex
+	tats map[string]time.Time
+	now  func() time.Time
+}
+
+// NewMemoryRateLimitBackend inits the in-memory backend.
+func NewMemoryRateLimitBackend() *MemoryRateLimitBackend {
+	return &MemoryRateLimitBackend{tats: map[string]time.Time{}, now: time.Now}
+}
+
+// memorySweepSize is the number of keys after which expired keys are removed.
+const memorySweepSize = 10000
+
+// Allow implements the RateLimitBackend.
+func (b *MemoryRateLimitBackend) Allow(
+	_ context.Context, key string, limit Limit,
+) (*LimitResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if len(b.tats) > memorySweepSize {
+		for k, tat := range b.tats {
+			if tat.Before(now) {
+				delete(b.tats, k)
+			}
+		}
+	}
+
+	interval := limit.Period / time.Duration(limit.Rate)
+
+	tat := b.tats[key]
+	if tat.Before(now) {
+		tat = now
+	}
+
+	newTat := tat.Add(interval)
+	diff := now.Sub(newTat.Add(-interval * time.Duration(limit.Burst)))
+
+	if diff < 0 {
+		return &LimitResult{RetryAfter: -diff, ResetAfter: tat.Sub(now)}, nil
+	}
+
+	b.tats[key] = newTat
+
+	return &LimitResult{
+		Allowed:    1,
+		Remaining:  int(diff / interval),
+		RetryAfter: -1,
+		ResetAfter: newTat.Sub(now),
+	}, nil
+}
+
+// ProvideRateLimiter provides the rate limiting interceptor with an in-memory backend. The rate limiter uses a
+// ClientIPFunc when it is provided.
+func ProvideRateLimiter() fx.Option {
+	return fx.Options(
+		fx.Provide(fx.Annotate(NewRateLimiter, fx.ParamTags(``, ``, ``, `optional:"true"`))),
+		fx.Provide(fx.Annotate(NewMemoryRateLimitBackend, fx.As(new(RateLimitBackend)))),
+	)
+}
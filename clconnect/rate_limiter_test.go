@@ -0,0 +1,198 @@
+package clconnect_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/crewlinker/clgo/clconnect"
+	"github.com/crewlinker/clgo/clconnect/clconnectredis"
+	clconnectv1 "github.com/crewlinker/clgo/clconnect/v1"
+	"github.com/crewlinker/clgo/clconnect/v1/clconnectv1connect"
+	"github.com/crewlinker/clgo/clredis"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+var _ = DescribeTable("rate limiting", func(ctx context.Context, backend func() fx.Option) {
+	var roc clconnectv1connect.ReadOnlyServiceClient
+	var rwc clconnectv1connect.ReadWriteServiceClient
+
+	app := fx.New(
+		fx.Populate(&roc, &rwc),
+		ProvideNoAuth(),
+		backend(),
+		fx.Decorate(func(c clconnect.Config) clconnect.Config {
+			c.RateLimits = map[string]int{clconnectv1connect.ReadOnlyServiceFooProcedure: 2}
+
+			return c
+		}),
+	)
+	Expect(app.Start(ctx)).To(Succeed())
+	DeferCleanup(app.Stop)
+
+	for i := 0; i < 2; i++ {
+		_, err := roc.Foo(ctx, connect.NewRequest(&clconnectv1.FooRequest{}))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	_, err := roc.Foo(ctx, connect.NewRequest(&clconnectv1.FooRequest{}))
+	Expect(connect.CodeOf(err)).To(Equal(connect.CodeResourceExhausted))
+
+	var cerr *connect.Error
+	Expect(errors.As(err, &cerr)).To(BeTrue())
+
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range cerr.Details() {
+		val, err := detail.Value()
+		Expect(err).ToNot(HaveOccurred())
+		if val, ok := val.(*errdetails.RetryInfo); ok {
+			retryInfo = val
+		}
+	}
+
+	Expect(retryInfo).ToNot(BeNil())
+	Expect(retryInfo.GetRetryDelay().AsDuration()).To(BeNumerically("~", time.Second*30, time.Second))
+
+	By("not limiting other procedures")
+	for i := 0; i < 3; i++ {
+		_, err := rwc.CheckHealth(ctx, connect.NewRequest(&clconnectv1.CheckHealthRequest{Echo: "foo"}))
+		Expect(err).ToNot(HaveOccurred())
+	}
+},
+	Entry("in memory", clconnect.ProvideRateLimiter),
+	Entry("redis", func() fx.Option {
+		mr := miniredis.NewMiniRedis()
+		Expect(mr.Start()).To(Succeed())
+		DeferCleanup(mr.Close)
+
+		return fx.Options(
+			clredis.Provide(),
+			clconnectredis.ProvideRateLimiter(),
+			fx.Decorate(func(c clredis.Config) clredis.Config {
+				c.Addrs = []string{mr.Addr()}
+
+				return c
+			}))
+	}),
+)
+
+var _ = Describe("rate limiting anonymous clients", func() {
+	var srv *httptest.Server
+
+	BeforeEach(func(ctx context.Context) {
+		app := fx.New(
+			fx.Populate(&srv),
+			ProvideNoAuth(),
+			clconnect.ProvideRateLimiter(),
+			fx.Decorate(func(c clconnect.Config) clconnect.Config {
+				c.RateLimits = map[string]int{clconnectv1connect.ReadOnlyServiceFooProcedure: 2}
+
+				return c
+			}),
+		)
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should limit across connections", func(ctx context.Context) {
+		// every request is done over a new connection, so from a different source port
+		roc := clconnectv1connect.NewReadOnlyServiceClient(
+			&http.Client{Transport: &http.Transport{DisableKeepAlives: true}}, srv.URL)
+
+		for range 2 {
+			_, err := roc.Foo(ctx, connect.NewRequest(&clconnectv1.FooRequest{}))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		_, err := roc.Foo(ctx, connect.NewRequest(&clconnectv1.FooRequest{}))
+		Expect(connect.CodeOf(err)).To(Equal(connect.CodeResourceExhausted))
+	})
+})
+
+var _ = Describe("rate limiting subjects", func() {
+	var (
+		roc clconnectv1connect.ReadOnlyServiceClient
+		ip  netip.Addr
+	)
+
+	BeforeEach(func(ctx context.Context) {
+		app := fx.New(
+			fx.Populate(&roc),
+			ProvideNoAuth(),
+			clconnect.ProvideRateLimiter(),
+			fx.Supply(clconnect.ClientIPFunc(func(context.Context) netip.Addr { return ip })),
+			fx.Decorate(func(c clconnect.Config) clconnect.Config {
+				c.RateLimits = map[string]int{clconnectv1connect.ReadOnlyServiceFooProcedure: 2}
+				c.SubjectRateLimits = map[string]int{"ip:10.0.0.1": 3, "ip:10.0.0.2": 0}
+
+				return c
+			}),
+		)
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	DescribeTable("should limit per subject", func(ctx context.Context, addr string, allowed int) {
+		ip = netip.MustParseAddr(addr)
+
+		for range allowed {
+			_, err := roc.Foo(ctx, connect.NewRequest(&clconnectv1.FooRequest{}))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		_, err := roc.Foo(ctx, connect.NewRequest(&clconnectv1.FooRequest{}))
+		if allowed > 3 {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(connect.CodeOf(err)).To(Equal(connect.CodeResourceExhausted))
+		}
+	},
+		Entry("procedure limit", "10.0.0.3", 2),
+		Entry("subject limit", "10.0.0.1", 3),
+		Entry("subject not limited", "10.0.0.2", 10),
+	)
+})
+
+// streamingConn is a handler conn for the spec and peer, other methods are not implemented.
+type streamingConn struct {
+	connect.StreamingHandlerConn
+	spec connect.Spec
+	peer connect.Peer
+}
+
+func (c streamingConn) Spec() connect.Spec { return c.spec }
+func (c streamingConn) Peer() connect.Peer { return c.peer }
+
+var _ = Describe("rate limiting streams", func() {
+	It("should limit starting streams", func(ctx context.Context) {
+		rl := clconnect.NewRateLimiter(clconnect.Config{
+			RateLimits:      map[string]int{"/foo.v1.FooService/Watch": 1},
+			RateLimitPeriod: time.Minute,
+		}, zap.NewNop(), clconnect.NewMemoryRateLimitBackend(), nil)
+
+		var started int
+		hdlr := rl.WrapStreamingHandler(func(context.Context, connect.StreamingHandlerConn) error {
+			started++
+
+			return nil
+		})
+
+		conn := streamingConn{
+			spec: connect.Spec{Procedure: "/foo.v1.FooService/Watch", StreamType: connect.StreamTypeServer},
+			peer: connect.Peer{Addr: "10.0.0.1:1234"},
+		}
+
+		Expect(hdlr(ctx, conn)).To(Succeed())
+		Expect(connect.CodeOf(hdlr(ctx, conn))).To(Equal(connect.CodeResourceExhausted))
+		Expect(started).To(Equal(1))
+	})
+})
@@ -28,7 +28,8 @@ func NewRecoverer(cfg Config, logs *zap.Logger) *Recoverer {
 }
 
 func (r *Recoverer) handle(ctx context.Context, _ connect.Spec, _ http.Header, v any) error {
-	clzap.Log(ctx, r.logs).Error("handling panic", zap.Any("recovered", v), zap.Stack("stack"))
+	// the panic passed through, and is reported by, the (error reporting) interceptors
+	clzap.Log(ctx, r.logs).Error("handling panic", zap.Any("recovered", v), zap.Stack("stack"), clzap.Handled())
 
 	cerr := connect.NewError(connect.CodeInternal, ErrServerPanic)
 
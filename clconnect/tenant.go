@@ -0,0 +1,121 @@
+package clconnect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crewlinker/clgo/clory"
+	"github.com/crewlinker/clgo/cltenant"
+	"github.com/crewlinker/clgo/clworkos"
+	"github.com/jackc/pgx/v5"
+)
+
+// Tenant describes the tenant a request is scoped to. It is used by the tenant transacters to scope the
+// database transaction using row-level security, they reject requests without a tenant ID.
+type Tenant = cltenant.Tenant
+
+// TenantResolver resolves the tenant from the (authenticated) request context.
+type TenantResolver = cltenant.Resolver
+
+// TenantResolverFunc implements the TenantResolver as a function.
+type TenantResolverFunc = cltenant.ResolverFunc
+
+// NewOpenIDTenantResolver resolves the tenant from a claim of the openid token as set by the JWTOPAAuth.
+func NewOpenIDTenantResolver(claim string) TenantResolver {
+	return TenantResolverFunc(func(ctx context.Context) (Tenant, error) {
+		v, ok := IdentityFromContext(ctx).Get(claim)
+		if !ok {
+			return Tenant{}, nil // anonymous, or no tenant claim
+		}
+
+		tid, ok := v.(string)
+		if !ok {
+			return Tenant{}, fmt.Errorf("tenant claim '%s' is not a string, got: %T", claim, v) //nolint:goerr113
+		}
+
+		return Tenant{ID: tid}, nil
+	})
+}
+
+// NewOryTenantResolver resolves the tenant from the public metadata of the identity in the Ory session
+// as set by the OryAuth.
+func NewOryTenantResolver(metadataKey string) TenantResolver {
+	return TenantResolverFunc(func(ctx context.Context) (Tenant, error) {
+		sess := clory.Session(ctx)
+		if sess == nil || sess.Identity == nil {
+			return Tenant{}, nil // no session
+		}
+
+		v, ok := sess.Identity.MetadataPublic[metadataKey]
+		if !ok {
+			return Tenant{}, nil // no tenant metadata
+		}
+
+		tid, ok := v.(string)
+		if !ok {
+			return Tenant{}, fmt.Errorf("tenant metadata '%s' is not a string, got: %T", metadataKey, v) //nolint:goerr113
+		}
+
+		return Tenant{ID: tid}, nil
+	})
+}
+
+// NewWorkOSTenantResolver resolves the tenant as the organization of the WorkOS identity.
+func NewWorkOSTenantResolver() TenantResolver {
+	return TenantResolverFunc(func(ctx context.Context) (Tenant, error) {
+		idn := clworkos.IdentityFromContext(ctx)
+		if !idn.IsValid {
+			return Tenant{}, nil // not authenticated
+		}
+
+		return Tenant{ID: idn.OrganizationID}, nil
+	})
+}
+
+// ErrNoTenant is returned when the tx is scoped to the tenant, but no tenant is resolved for the request.
+var ErrNoTenant = errors.New("no tenant resolved for the request")
+
+// ErrNoRLSDefaultRole is returned when transactions are scoped to tenants but no default role is configured.
+var ErrNoRLSDefaultRole = errors.New("a tenant resolver is provided but no RLS default role is configured")
+
+// checkTenantRole checks that a (less privileged) default role is configured when transactions are scoped to
+// tenants. Without it the tx runs as the table owner (or a superuser) for which the policies are not enforced.
+func checkTenantRole(cfg Config, tres TenantResolver) error {
+	if tres != nil && cfg.RLSDefaultRole == "" {
+		return ErrNoRLSDefaultRole
+	}
+
+	return nil
+}
+
+// scopeTenant will resolve the tenant and scope the tx to it by setting the tenant id as a local setting, and
+// optionally assume a (less privileged) role. The settings are local so they end with the tx.
+func scopeTenant(ctx context.Context, cfg Config, tres TenantResolver, tx pgx.Tx) error {
+	tnt, err := tres.ResolveTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to resolve tenant: %w", err)
+	}
+
+	// the setting can't be reset to NULL within the tx, an empty tenant would only be safe if every policy
+	// handles it. So we rather reject the request.
+	if tnt.ID == "" {
+		return ErrNoTenant
+	}
+
+	if tnt.Role == "" {
+		tnt.Role = cfg.RLSDefaultRole
+	}
+
+	if tnt.Role != "" {
+		if _, err := tx.Exec(ctx, `SET LOCAL ROLE `+pgx.Identifier{tnt.Role}.Sanitize()); err != nil {
+			return fmt.Errorf("failed to set local role: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT set_config($1, $2, true)`, cfg.RLSTenantSetting, tnt.ID); err != nil {
+		return fmt.Errorf("failed to set local tenant: %w", err)
+	}
+
+	return nil
+}
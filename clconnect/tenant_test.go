@@ -0,0 +1,53 @@
+package clconnect_test
+
+import (
+	"context"
+
+	"github.com/crewlinker/clgo/clconnect"
+	"github.com/crewlinker/clgo/clory"
+	"github.com/crewlinker/clgo/clworkos"
+	"github.com/lestrrat-go/jwx/v2/jwt/openid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	orysdk "github.com/ory/client-go"
+)
+
+var _ = Describe("tenant resolvers", func() {
+	It("should resolve from openid claim", func(ctx context.Context) {
+		tok := openid.New()
+		Expect(tok.Set("tid", "tenant1")).To(Succeed())
+
+		tnt, err := clconnect.NewOpenIDTenantResolver("tid").ResolveTenant(clconnect.WithIdentity(ctx, tok))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tnt.ID).To(Equal("tenant1"))
+
+		tnt, err = clconnect.NewOpenIDTenantResolver("tid").ResolveTenant(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tnt.ID).To(BeEmpty())
+
+		Expect(tok.Set("tid", 42)).To(Succeed())
+		_, err = clconnect.NewOpenIDTenantResolver("tid").ResolveTenant(clconnect.WithIdentity(ctx, tok))
+		Expect(err).To(MatchError(MatchRegexp(`not a string`)))
+	})
+
+	It("should resolve from ory session", func(ctx context.Context) {
+		sess := orysdk.NewSession("sess1")
+		sess.Identity = &orysdk.Identity{MetadataPublic: map[string]any{"tenant_id": "tenant2"}}
+
+		tnt, err := clconnect.NewOryTenantResolver("tenant_id").ResolveTenant(clory.WithSession(ctx, sess))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tnt.ID).To(Equal("tenant2"))
+
+		tnt, err = clconnect.NewOryTenantResolver("tenant_id").ResolveTenant(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tnt.ID).To(BeEmpty())
+	})
+
+	It("should resolve from workos identity", func(ctx context.Context) {
+		ctx = clworkos.WithIdentity(ctx, clworkos.Identity{IsValid: true, OrganizationID: "org_1"})
+
+		tnt, err := clconnect.NewWorkOSTenantResolver().ResolveTenant(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tnt.ID).To(Equal("org_1"))
+	})
+})
@@ -0,0 +1,257 @@
+// Package clenthook provides re-usable Ent hooks, interceptors and privacy rules.
+package clenthook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/crewlinker/clgo/clconnect"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// CreatedByField is the name of the field that is set to the subject that created the entity.
+	CreatedByField = "created_by"
+	// UpdatedByField is the name of the field that is set to the subject that last updated the entity.
+	UpdatedByField = "updated_by"
+	// DeletedAtField is the name of the field that is set when an entity is soft deleted.
+	DeletedAtField = "deleted_at"
+)
+
+// tracerName identifies the instrumentation.
+const tracerName = "github.com/crewlinker/clgo/clent"
+
+// Tracing returns a hook that creates a span for every mutation.
+func Tracing(tp trace.TracerProvider) ent.Hook {
+	tracer := tp.Tracer(tracerName)
+
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			ctx, span := tracer.Start(ctx, "ent.mutate "+m.Type(),
+				trace.WithSpanKind(trace.SpanKindInternal),
+				trace.WithAttributes(
+					attribute.String("ent.type", m.Type()),
+					attribute.String("ent.op", m.Op().String())))
+			defer span.End()
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return v, err //nolint:wrapcheck
+		})
+	}
+}
+
+// TracingInterceptor returns an interceptor that creates a span for every query.
+func TracingInterceptor(tp trace.TracerProvider) ent.Interceptor {
+	tracer := tp.Tracer(tracerName)
+
+	return ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			typ, op := fmt.Sprintf("%T", q), "unknown"
+			if qc := ent.QueryFromContext(ctx); qc != nil {
+				typ, op = qc.Type, qc.Op
+			}
+
+			ctx, span := tracer.Start(ctx, "ent.query "+typ,
+				trace.WithSpanKind(trace.SpanKindInternal),
+				trace.WithAttributes(
+					attribute.String("ent.type", typ),
+					attribute.String("ent.op", op)))
+			defer span.End()
+
+			v, err := next.Query(ctx, q)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return v, err //nolint:wrapcheck
+		})
+	})
+}
+
+// AuditFields returns a hook that sets the created_by field on creation, and the updated_by field on
+// every creation and update. The value is the subject of the request identity, mutations by an anonymous
+// identity are not annotated. It should only be used on schemas that have both fields.
+func AuditFields() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			sub := clconnect.IdentityFromContext(ctx).Subject()
+			if sub == "" || !m.Op().Is(ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne) {
+				return next.Mutate(ctx, m)
+			}
+
+			if m.Op().Is(ent.OpCreate) {
+				if err := m.SetField(CreatedByField, sub); err != nil {
+					return nil, fmt.Errorf("failed to set %s: %w", CreatedByField, err)
+				}
+			}
+
+			if err := m.SetField(UpdatedByField, sub); err != nil {
+				return nil, fmt.Errorf("failed to set %s: %w", UpdatedByField, err)
+			}
+
+			return next.Mutate(ctx, m)
+		})
+	}
+}
+
+// Mutater is implemented by generated Ent clients, it is used to re-route mutations.
+type Mutater interface {
+	Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error)
+}
+
+// softDeleteMutation is implemented by generated mutations.
+type softDeleteMutation[C Mutater] interface {
+	ent.Mutation
+	SetOp(op ent.Op)
+	Client() C
+	WhereP(ps ...func(*sql.Selector))
+}
+
+// SoftDelete returns a hook that turns delete mutations into updates that set the deleted_at field. The
+// type parameter must be the generated client, e.g: SoftDelete[*ent.Client]().
+func SoftDelete[C Mutater]() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if !m.Op().Is(ent.OpDelete|ent.OpDeleteOne) || IsSoftDeleteSkipped(ctx) {
+				return next.Mutate(ctx, m)
+			}
+
+			smut, ok := m.(softDeleteMutation[C])
+			if !ok {
+				return nil, fmt.Errorf("clenthook: unexpected mutation type for soft delete: %T", m) //nolint:goerr113
+			}
+
+			smut.WhereP(sql.FieldIsNull(DeletedAtField))
+			smut.SetOp(ent.OpUpdate)
+
+			if err := smut.SetField(DeletedAtField, time.Now()); err != nil {
+				return nil, fmt.Errorf("failed to set %s: %w", DeletedAtField, err)
+			}
+
+			return smut.Client().Mutate(ctx, smut) //nolint:wrapcheck
+		})
+	}
+}
+
+// SoftDeleteFilter returns an interceptor that filters out soft deleted entities.
+func SoftDeleteFilter() ent.Interceptor {
+	return ent.TraverseFunc(func(ctx context.Context, q ent.Query) error {
+		if IsSoftDeleteSkipped(ctx) {
+			return nil
+		}
+
+		wq, ok := q.(interface {
+			WhereP(ps ...func(*sql.Selector))
+		})
+		if !ok {
+			return fmt.Errorf("clenthook: unexpected query type for soft delete: %T", q) //nolint:goerr113
+		}
+
+		wq.WhereP(sql.FieldIsNull(DeletedAtField))
+
+		return nil
+	})
+}
+
+// scope context values.
+type ctxKey string
+
+// SkipSoftDelete returns a context that causes queries to include soft deleted entities, and deletes
+// to remove entities permanently.
+func SkipSoftDelete(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey("skip_soft_delete"), true)
+}
+
+// IsSoftDeleteSkipped returns whether the soft delete logic is skipped for the context.
+func IsSoftDeleteSkipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(ctxKey("skip_soft_delete")).(bool)
+
+	return skip
+}
+
+// ErrVersionMismatch is returned when an entity is updated with a version that is not the current version.
+var ErrVersionMismatch = errors.New("clenthook: version mismatch, the entity was modified concurrently")
+
+// OptimisticLock returns a hook that increments the version field on every update. When an update sets
+// the version field explicitly, it is used as the expected current version and the update fails with
+// ErrVersionMismatch if the entity was modified in the meantime. A bulk update with an explicit version fails
+// with ErrVersionMismatch when it updates no entities.
+func OptimisticLock(field string) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if !m.Op().Is(ent.OpUpdate | ent.OpUpdateOne) {
+				return next.Mutate(ctx, m)
+			}
+
+			expv, ok := m.Field(field)
+			if !ok {
+				if err := m.AddField(field, 1); err != nil {
+					return nil, fmt.Errorf("failed to increment %s: %w", field, err)
+				}
+
+				return next.Mutate(ctx, m)
+			}
+
+			exp, ok := expv.(int)
+			if !ok {
+				return nil, fmt.Errorf("clenthook: version field %s is not an int, got: %T", field, expv) //nolint:goerr113
+			}
+
+			if m.Op().Is(ent.OpUpdateOne) {
+				cur, err := m.OldField(ctx, field)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get current %s: %w", field, err)
+				}
+
+				if cur != exp {
+					return nil, ErrVersionMismatch
+				}
+			}
+
+			wmut, ok := m.(interface {
+				WhereP(ps ...func(*sql.Selector))
+			})
+			if !ok {
+				return nil, fmt.Errorf("clenthook: unexpected mutation type for optimistic lock: %T", m) //nolint:goerr113
+			}
+
+			// the predicate protects against concurrent updates between reading and writing the version
+			wmut.WhereP(sql.FieldEQ(field, exp))
+
+			if err := m.SetField(field, exp+1); err != nil {
+				return nil, fmt.Errorf("failed to set %s: %w", field, err)
+			}
+
+			// with the predicate, an entity that is modified concurrently is no longer found or updated
+			val, err := next.Mutate(ctx, m)
+			switch {
+			case err != nil && m.Op().Is(ent.OpUpdateOne) && isNotFound(err):
+				return nil, ErrVersionMismatch
+			case err == nil && m.Op().Is(ent.OpUpdate) && val == 0:
+				return nil, ErrVersionMismatch
+			}
+
+			return val, err
+		})
+	}
+}
+
+// isNotFound reports whether the error is the NotFoundError that Ent generates for each client package.
+func isNotFound(err error) bool {
+	typ := reflect.TypeOf(err)
+
+	return typ != nil && typ.Kind() == reflect.Pointer && typ.Elem().Name() == "NotFoundError"
+}
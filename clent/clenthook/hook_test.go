@@ -0,0 +1,192 @@
+package clenthook_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/privacy"
+	"github.com/crewlinker/clgo/clconnect"
+	"github.com/crewlinker/clgo/clent/clenthook"
+	"github.com/lestrrat-go/jwx/v2/jwt/openid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClenthook(t *testing.T) {
+	t.Parallel()
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "clent/clenthook")
+}
+
+var _ = Describe("tracing", func() {
+	var exp *tracetest.InMemoryExporter
+	var trp *sdktrace.TracerProvider
+
+	BeforeEach(func() {
+		exp = tracetest.NewInMemoryExporter()
+		trp = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	})
+
+	It("should trace mutations", func(ctx context.Context) {
+		mut := clenthook.Tracing(trp)(ent.MutateFunc(func(context.Context, ent.Mutation) (ent.Value, error) {
+			return nil, errors.New("fail")
+		}))
+
+		_, err := mut.Mutate(ctx, &testMutation{op: ent.OpCreate})
+		Expect(err).To(MatchError("fail"))
+		Expect(exp.GetSpans()).To(HaveLen(1))
+		Expect(exp.GetSpans()[0].Name).To(Equal("ent.mutate User"))
+		Expect(exp.GetSpans()[0].Status.Description).To(Equal("fail"))
+	})
+
+	It("should trace queries", func(ctx context.Context) {
+		qrr := clenthook.TracingInterceptor(trp).Intercept(ent.QuerierFunc(func(context.Context, ent.Query) (ent.Value, error) {
+			return 1, nil
+		}))
+
+		ctx = ent.NewQueryContext(ctx, &ent.QueryContext{Type: "User", Op: "All"})
+		Expect(qrr.Query(ctx, &testQuery{})).To(Equal(1))
+		Expect(exp.GetSpans()).To(HaveLen(1))
+		Expect(exp.GetSpans()[0].Name).To(Equal("ent.query User"))
+	})
+})
+
+var _ = Describe("audit", func() {
+	var next ent.Mutator
+
+	BeforeEach(func() {
+		next = ent.MutateFunc(func(context.Context, ent.Mutation) (ent.Value, error) { return nil, nil })
+	})
+
+	It("should set created and updated by", func(ctx context.Context) {
+		mut := &testMutation{op: ent.OpCreate}
+		_, err := clenthook.AuditFields()(next).Mutate(withSubject(ctx, "user1"), mut)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mut.fields).To(Equal(map[string]ent.Value{"created_by": "user1", "updated_by": "user1"}))
+	})
+
+	It("should set only updated by", func(ctx context.Context) {
+		mut := &testMutation{op: ent.OpUpdateOne}
+		_, err := clenthook.AuditFields()(next).Mutate(withSubject(ctx, "user1"), mut)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mut.fields).To(Equal(map[string]ent.Value{"updated_by": "user1"}))
+	})
+
+	It("should not set anything for anonymous identities", func(ctx context.Context) {
+		mut := &testMutation{op: ent.OpCreate}
+		_, err := clenthook.AuditFields()(next).Mutate(ctx, mut)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mut.fields).To(BeEmpty())
+	})
+})
+
+var _ = Describe("soft delete", func() {
+	It("should turn deletes into updates", func(ctx context.Context) {
+		var called bool
+
+		mut := &testMutation{op: ent.OpDeleteOne}
+		mut.client = &testClient{fn: func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			called = true
+
+			return 1, nil
+		}}
+
+		_, err := clenthook.SoftDelete[*testClient]()(nil).Mutate(ctx, mut)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(called).To(BeTrue())
+		Expect(mut.op).To(Equal(ent.OpUpdate))
+		Expect(mut.fields).To(HaveKey("deleted_at"))
+		Expect(mut.preds).To(HaveLen(1))
+	})
+
+	It("should delete permanently when skipped", func(ctx context.Context) {
+		var called bool
+
+		next := ent.MutateFunc(func(context.Context, ent.Mutation) (ent.Value, error) {
+			called = true
+
+			return 1, nil
+		})
+
+		mut := &testMutation{op: ent.OpDelete}
+		_, err := clenthook.SoftDelete[*testClient]()(next).Mutate(clenthook.SkipSoftDelete(ctx), mut)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(called).To(BeTrue())
+		Expect(mut.op).To(Equal(ent.OpDelete))
+	})
+
+	It("should filter queries", func(ctx context.Context) {
+		qry := &testQuery{}
+		Expect(clenthook.SoftDeleteFilter().(ent.Traverser).Traverse(ctx, qry)).To(Succeed())
+		Expect(qry.preds).To(HaveLen(1))
+
+		qry = &testQuery{}
+		Expect(clenthook.SoftDeleteFilter().(ent.Traverser).Traverse(clenthook.SkipSoftDelete(ctx), qry)).To(Succeed())
+		Expect(qry.preds).To(BeEmpty())
+	})
+})
+
+var _ = Describe("privacy", func() {
+	It("should deny anonymous", func(ctx context.Context) {
+		Expect(clenthook.DenyIfAnonymous().EvalQuery(ctx, nil)).To(MatchError(privacy.Deny))
+		Expect(clenthook.DenyIfAnonymous().EvalQuery(withSubject(ctx, "user1"), nil)).To(MatchError(privacy.Skip))
+	})
+
+	It("should allow if claim matches", func(ctx context.Context) {
+		ctx = withSubject(ctx, "user1")
+		Expect(clenthook.AllowIfClaim("sub", "user1").EvalMutation(ctx, nil)).To(MatchError(privacy.Allow))
+		Expect(clenthook.AllowIfClaim("sub", "user2").EvalMutation(ctx, nil)).To(MatchError(privacy.Skip))
+	})
+})
+
+func withSubject(ctx context.Context, sub string) context.Context {
+	tok := openid.New()
+	Expect(tok.Set("sub", sub)).To(Succeed())
+
+	return clconnect.WithIdentity(ctx, tok)
+}
+
+// testClient mimics the generated client.
+type testClient struct {
+	fn ent.MutateFunc
+}
+
+func (c *testClient) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	return c.fn(ctx, m)
+}
+
+// testMutation mimics a generated mutation, only the methods used by the hooks are implemented.
+type testMutation struct {
+	ent.Mutation
+	op     ent.Op
+	fields map[string]ent.Value
+	preds  []func(*sql.Selector)
+	client *testClient
+}
+
+func (m *testMutation) Op() ent.Op                       { return m.op }
+func (m *testMutation) SetOp(op ent.Op)                  { m.op = op }
+func (m *testMutation) Type() string                     { return "User" }
+func (m *testMutation) Client() *testClient              { return m.client }
+func (m *testMutation) WhereP(ps ...func(*sql.Selector)) { m.preds = append(m.preds, ps...) }
+func (m *testMutation) SetField(name string, v ent.Value) error {
+	if m.fields == nil {
+		m.fields = map[string]ent.Value{}
+	}
+
+	m.fields[name] = v
+
+	return nil
+}
+
+// testQuery mimics a generated query.
+type testQuery struct {
+	preds []func(*sql.Selector)
+}
+
+func (q *testQuery) WhereP(ps ...func(*sql.Selector)) { q.preds = append(q.preds, ps...) }
@@ -0,0 +1,33 @@
+package clenthook
+
+import (
+	"context"
+	"reflect"
+
+	"entgo.io/ent/privacy"
+	"github.com/crewlinker/clgo/clconnect"
+)
+
+// DenyIfAnonymous returns a privacy rule that denies queries and mutations when the request identity
+// is anonymous. Otherwise it skips to the next rule.
+func DenyIfAnonymous() privacy.QueryMutationRule {
+	return privacy.ContextQueryMutationRule(func(ctx context.Context) error {
+		if clconnect.IdentityFromContext(ctx).Subject() == "" {
+			return privacy.Denyf("clenthook: anonymous identity")
+		}
+
+		return privacy.Skip
+	})
+}
+
+// AllowIfClaim returns a privacy rule that allows queries and mutations when the claim of the request
+// identity equals the value. Otherwise it skips to the next rule.
+func AllowIfClaim(claim string, value any) privacy.QueryMutationRule {
+	return privacy.ContextQueryMutationRule(func(ctx context.Context) error {
+		if v, ok := clconnect.IdentityFromContext(ctx).Get(claim); ok && reflect.DeepEqual(v, value) {
+			return privacy.Allowf("clenthook: claim '%s' matches", claim)
+		}
+
+		return privacy.Skip
+	})
+}
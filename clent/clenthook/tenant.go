@@ -0,0 +1,85 @@
+package clenthook
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/privacy"
+	"github.com/crewlinker/clgo/clid"
+	"github.com/crewlinker/clgo/cltenant"
+)
+
+// TenantPolicy returns a privacy policy that scopes queries and mutations to the tenant that is resolved
+// from the context. Queries and updates are filtered on the tenant field, and the tenant field is set on
+// creation. Without a tenant the operation is denied. The policy can be bypassed with privacy.DecisionContext.
+func TenantPolicy(field string, tres cltenant.Resolver) ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{queryRuleFunc(func(ctx context.Context, q ent.Query) error {
+			tid, err := resolveTenantID(ctx, tres)
+			if err != nil {
+				return err
+			}
+
+			wq, ok := q.(interface {
+				WhereP(ps ...func(*sql.Selector))
+			})
+			if !ok {
+				return privacy.Denyf("clenthook: unexpected query type for tenant policy: %T", q)
+			}
+
+			wq.WhereP(sql.FieldEQ(field, tid))
+
+			return privacy.Skip
+		})},
+		Mutation: privacy.MutationPolicy{privacy.MutationRuleFunc(func(ctx context.Context, m ent.Mutation) error {
+			tid, err := resolveTenantID(ctx, tres)
+			if err != nil {
+				return err
+			}
+
+			if m.Op().Is(ent.OpCreate) {
+				if err := m.SetField(field, tid); err != nil {
+					return privacy.Denyf("clenthook: failed to set tenant: %v", err)
+				}
+
+				return privacy.Skip
+			}
+
+			wm, ok := m.(interface {
+				WhereP(ps ...func(*sql.Selector))
+			})
+			if !ok {
+				return privacy.Denyf("clenthook: unexpected mutation type for tenant policy: %T", m)
+			}
+
+			wm.WhereP(sql.FieldEQ(field, tid))
+
+			return privacy.Skip
+		})},
+	}
+}
+
+// queryRuleFunc implements the query rule as a function.
+type queryRuleFunc func(ctx context.Context, q ent.Query) error
+
+func (f queryRuleFunc) EvalQuery(ctx context.Context, q ent.Query) error { return f(ctx, q) }
+
+// resolveTenantID resolves the tenant as a clid, or returns a deny decision.
+func resolveTenantID(ctx context.Context, tres cltenant.Resolver) (tid clid.ID, err error) {
+	tnt, err := tres.ResolveTenant(ctx)
+	if err != nil {
+		return tid, privacy.Denyf("clenthook: failed to resolve tenant: %v", err)
+	}
+
+	if tnt.ID == "" {
+		return tid, privacy.Denyf("clenthook: no tenant in context")
+	}
+
+	if err := tid.Scan(tnt.ID); err != nil {
+		return tid, fmt.Errorf("%w: invalid tenant id: %w", privacy.Deny, err)
+	}
+
+	return tid, nil
+}
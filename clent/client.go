@@ -0,0 +1,50 @@
+package clent
+
+import (
+	"database/sql"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/crewlinker/clgo/clent/clenthook"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+)
+
+// Client constrains the Ent clients this package can provide to those generated by Ent.
+type Client interface {
+	Use(hooks ...ent.Hook)
+	Intercept(interceptors ...ent.Interceptor)
+}
+
+// ConstructClient is the function signature for constructing a generated Ent client from a driver. It
+// should be provided to the DI container, e.g: func(d dialect.Driver) *ent.Client { return ent.NewClient(ent.Driver(d)) }.
+type ConstructClient[C Client] func(drv dialect.Driver) C
+
+// NewClient inits an Ent client on top of the standard library connection pool. If a tracer provider
+// is available every query and mutation will be traced.
+func NewClient[C Client](db *sql.DB, ctor ConstructClient[C], tp trace.TracerProvider) C {
+	client := ctor(entsql.OpenDB(dialect.Postgres, db))
+	if tp != nil {
+		client.Use(clenthook.Tracing(tp))
+		client.Intercept(clenthook.TracingInterceptor(tp))
+	}
+
+	return client
+}
+
+// moduleName for naming conventions.
+const moduleName = "clent"
+
+// Provide a read-only and read-write Ent client, named "ro" and "rw", from the database connections
+// provided by clpostgres. The clients don't need to be closed since the clpostgres owns the connections.
+func Provide[C Client]() fx.Option {
+	return fx.Module(moduleName,
+		fx.Provide(fx.Annotate(NewClient[C],
+			fx.ParamTags(`name:"ro"`, ``, `optional:"true"`),
+			fx.ResultTags(`name:"ro"`))),
+		fx.Provide(fx.Annotate(NewClient[C],
+			fx.ParamTags(`name:"rw"`, ``, `optional:"true"`),
+			fx.ResultTags(`name:"rw"`))),
+	)
+}
@@ -0,0 +1,51 @@
+package clent_test
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"github.com/crewlinker/clgo/clent"
+	"github.com/crewlinker/clgo/clotel"
+	"github.com/crewlinker/clgo/clpostgres"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+)
+
+var _ = Describe("client", func() {
+	var ro, rw *testClient
+
+	BeforeEach(func() {
+		app := fx.New(
+			fx.Populate(
+				fx.Annotate(&ro, fx.ParamTags(`name:"ro"`)),
+				fx.Annotate(&rw, fx.ParamTags(`name:"rw"`))),
+			fx.Supply(clent.ConstructClient[*testClient](func(d dialect.Driver) *testClient {
+				return &testClient{drv: d}
+			})),
+			clent.Provide[*testClient](),
+			clpostgres.Provide(),
+			clotel.TestProvide(),
+			clzap.TestProvide())
+		Expect(app.Err()).ToNot(HaveOccurred())
+	})
+
+	It("should provide instrumented clients", func() {
+		Expect(ro).ToNot(BeNil())
+		Expect(rw).ToNot(BeNil())
+		Expect(ro).ToNot(BeIdenticalTo(rw))
+		Expect(ro.drv.Dialect()).To(Equal(dialect.Postgres))
+		Expect(rw.hooks).To(HaveLen(1))
+		Expect(rw.inters).To(HaveLen(1))
+	})
+})
+
+// testClient mimics a generated ent client.
+type testClient struct {
+	drv    dialect.Driver
+	hooks  []ent.Hook
+	inters []ent.Interceptor
+}
+
+func (c *testClient) Use(hooks ...ent.Hook)               { c.hooks = append(c.hooks, hooks...) }
+func (c *testClient) Intercept(inters ...ent.Interceptor) { c.inters = append(c.inters, inters...) }
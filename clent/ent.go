@@ -9,14 +9,17 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/field"
 	"github.com/crewlinker/clgo/clid"
+	"github.com/jackc/pgx/v5"
 	"github.com/oklog/ulid/v2"
 )
 
 // Field is a namespace for re-usable fields.
 var Field = struct {
-	CLID func(string, string) ent.Field
+	CLID       func(string, string) ent.Field
+	CLIDNative func(string, string) ent.Field
 }{
 	// CLID builds an prefixed-ulid ID field with the given name.
 	CLID: func(name, prefix string) ent.Field {
@@ -35,6 +38,35 @@ var Field = struct {
 				dialect.MySQL:    fmt.Sprintf("varchar(%d)", clid.StorageSize),
 			})
 	},
+	// CLIDNative builds a prefixed-ulid ID field that is stored in the native Postgres composite type as created by
+	// clpgxmigrate.NewCLIDTypeStep. Edges to entities with such an ID will use the same storage.
+	CLIDNative: func(name, prefix string) ent.Field {
+		if len(prefix) != clid.PrefixSize {
+			panic("clent: clid prefix not of length: " + strconv.Itoa(clid.PrefixSize))
+		}
+
+		return field.String(name).
+			GoType(clid.Native{}).
+			DefaultFunc(func() clid.Native {
+				return clid.Native{ID: clid.New(prefix)}
+			}).
+			Validate(Validator.CLID(prefix)).
+			SchemaType(map[string]string{
+				dialect.Postgres: clid.PostgresTypeName,
+			})
+	},
+}
+
+// CLIDNativeCheck returns a schema annotation that checks the prefix of a native clid column.
+func CLIDNativeCheck(column, prefix string) *entsql.Annotation {
+	if len(prefix) != clid.PrefixSize {
+		panic("clent: clid prefix not of length: " + strconv.Itoa(clid.PrefixSize))
+	}
+
+	return entsql.Checks(map[string]string{
+		column + "_clid_prefix": fmt.Sprintf(`(%s).prefix = '%s'`,
+			pgx.Identifier{column}.Sanitize(), strings.ReplaceAll(prefix, "'", "''")),
+	})
 }
 
 // Validator name-spaced re-usable (field validators).
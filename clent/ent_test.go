@@ -1,10 +1,23 @@
 package clent_test
 
 import (
+	"context"
+	"database/sql"
+	"io/fs"
 	"testing"
 
 	"entgo.io/ent/dialect"
 	"github.com/crewlinker/clgo/clent"
+	"github.com/crewlinker/clgo/clent/internal/entgen"
+	"github.com/crewlinker/clgo/clent/internal/entgen/member"
+	"github.com/crewlinker/clgo/clent/internal/entgen/team"
+	"github.com/crewlinker/clgo/clid"
+	"github.com/crewlinker/clgo/clpostgres"
+	"github.com/crewlinker/clgo/clpostgres/clpgmigrate"
+	"github.com/crewlinker/clgo/clzap"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -32,6 +45,25 @@ var _ = Describe("clid field", func() {
 		}))
 	})
 
+	It("should have constructed correct native field", func() {
+		field := clent.Field.CLIDNative("id3", "orga")
+
+		Expect(field.Descriptor().Name).To(Equal(`id3`))
+		Expect(field.Descriptor().SchemaType).To(Equal(map[string]string{
+			dialect.Postgres: "clid",
+		}))
+		Expect(field.Descriptor().Default.(func() clid.Native)().Prefix()).To(Equal("orga"))
+	})
+
+	It("should construct native prefix check", func() {
+		Expect(clent.CLIDNativeCheck("id", "orga").Checks).To(Equal(map[string]string{
+			"id_clid_prefix": `("id").prefix = 'orga'`,
+		}))
+		Expect(clent.CLIDNativeCheck("Id", "o'rg").Checks).To(Equal(map[string]string{
+			"Id_clid_prefix": `("Id").prefix = 'o''rg'`,
+		}))
+	})
+
 	It("should validate prefix", func() {
 		Expect(clent.Validator.CLID("foo")("foo-01HMEFW25X3NAWEDGMPPYM1C6K")).To(Succeed())
 		Expect(clent.Validator.CLID("foo")("bar-01HMEFW25X3NAWEDGMPPYM1C6K")).To(
@@ -45,3 +77,52 @@ var _ = Describe("clid field", func() {
 			MatchError(ContainSubstring(`invalid ulid`)))
 	})
 })
+
+var _ = Describe("native clid storage", func() {
+	var client *entgen.Client
+	var db *sql.DB
+
+	BeforeEach(func(ctx context.Context) {
+		app := fx.New(
+			fx.Populate(fx.Annotate(&client, fx.ParamTags(`name:"rw"`)), fx.Annotate(&db, fx.ParamTags(`name:"rw"`))),
+			fx.Supply(clent.ConstructClient[*entgen.Client](func(d dialect.Driver) *entgen.Client {
+				return entgen.NewClient(entgen.Driver(d))
+			})),
+			clent.Provide[*entgen.Client](),
+			clzap.TestProvide(),
+			clpostgres.TestProvide(),
+			clpgmigrate.GooseMigrated(lo.Must(fs.Sub(migrations, "testdata/migrations"))),
+		)
+
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should store ids and edges natively", func(ctx context.Context) {
+		tm := client.Team.Create().SetName("foo").SaveX(ctx)
+		Expect(tm.ID.Prefix()).To(Equal("team"))
+
+		mbr := client.Member.Create().SetName("bar").SetTeam(tm).SaveX(ctx)
+		Expect(mbr.ID.Prefix()).To(Equal("mmbr"))
+
+		var prefix, typ string
+		Expect(db.QueryRowContext(ctx, `SELECT (team_members).prefix, pg_typeof(team_members)::text FROM members`).
+			Scan(&prefix, &typ)).To(Succeed())
+		Expect(prefix).To(Equal("team"))
+		Expect(typ).To(Equal(clid.PostgresTypeName))
+
+		By("querying by id and through the edges")
+		Expect(client.Team.Query().Where(team.ID(tm.ID)).OnlyX(ctx).Name).To(Equal("foo"))
+		Expect(client.Member.Query().Where(member.HasTeamWith(team.ID(tm.ID))).OnlyIDX(ctx)).To(Equal(mbr.ID))
+		Expect(mbr.QueryTeam().OnlyIDX(ctx)).To(Equal(tm.ID))
+
+		tm = client.Team.Query().Where(team.ID(tm.ID)).WithMembers().OnlyX(ctx)
+		Expect(tm.Edges.Members).To(HaveLen(1))
+		Expect(tm.Edges.Members[0].ID).To(Equal(mbr.ID))
+	})
+
+	It("should check the prefix", func(ctx context.Context) {
+		_, err := db.ExecContext(ctx, `INSERT INTO teams VALUES ($1, 'foo')`, clid.Native{ID: clid.New("mmbr")})
+		Expect(err).To(MatchError(ContainSubstring(`id_clid_prefix`)))
+	})
+})
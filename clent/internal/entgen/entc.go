@@ -0,0 +1,18 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+
+	"entgo.io/ent/entc"
+	"entgo.io/ent/entc/gen"
+)
+
+func main() {
+	if err := entc.Generate("./schema", &gen.Config{
+		Features: []gen.Feature{gen.FeaturePrivacy, gen.FeatureEntQL},
+	}); err != nil {
+		log.Fatalf("failed to run ent codegen: %v", err)
+	}
+}
@@ -0,0 +1,286 @@
+// Code generated by ent, DO NOT EDIT.
+
+package entgen
+
+import (
+	"github.com/crewlinker/clgo/clent/internal/entgen/member"
+	"github.com/crewlinker/clgo/clent/internal/entgen/note"
+	"github.com/crewlinker/clgo/clent/internal/entgen/predicate"
+	"github.com/crewlinker/clgo/clent/internal/entgen/team"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/entql"
+	"entgo.io/ent/schema/field"
+)
+
+// schemaGraph holds a representation of ent/schema at runtime.
+var schemaGraph = func() *sqlgraph.Schema {
+	graph := &sqlgraph.Schema{Nodes: make([]*sqlgraph.Node, 3)}
+	graph.Nodes[0] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   member.Table,
+			Columns: member.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: member.FieldID,
+			},
+		},
+		Type: "Member",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			member.FieldName: {Type: field.TypeString, Column: member.FieldName},
+		},
+	}
+	graph.Nodes[1] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   note.Table,
+			Columns: note.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: note.FieldID,
+			},
+		},
+		Type: "Note",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			note.FieldCreatedAt: {Type: field.TypeTime, Column: note.FieldCreatedAt},
+			note.FieldUpdatedAt: {Type: field.TypeTime, Column: note.FieldUpdatedAt},
+			note.FieldDeletedAt: {Type: field.TypeTime, Column: note.FieldDeletedAt},
+			note.FieldTenantID:  {Type: field.TypeString, Column: note.FieldTenantID},
+			note.FieldVersion:   {Type: field.TypeInt, Column: note.FieldVersion},
+			note.FieldBody:      {Type: field.TypeString, Column: note.FieldBody},
+		},
+	}
+	graph.Nodes[2] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   team.Table,
+			Columns: team.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeString,
+				Column: team.FieldID,
+			},
+		},
+		Type: "Team",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			team.FieldName: {Type: field.TypeString, Column: team.FieldName},
+		},
+	}
+	graph.MustAddE(
+		"team",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   member.TeamTable,
+			Columns: []string{member.TeamColumn},
+			Bidi:    false,
+		},
+		"Member",
+		"Team",
+	)
+	graph.MustAddE(
+		"members",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   team.MembersTable,
+			Columns: []string{team.MembersColumn},
+			Bidi:    false,
+		},
+		"Team",
+		"Member",
+	)
+	return graph
+}()
+
+// predicateAdder wraps the addPredicate method.
+// All update, update-one and query builders implement this interface.
+type predicateAdder interface {
+	addPredicate(func(s *sql.Selector))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (mq *MemberQuery) addPredicate(pred func(s *sql.Selector)) {
+	mq.predicates = append(mq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the MemberQuery builder.
+func (mq *MemberQuery) Filter() *MemberFilter {
+	return &MemberFilter{config: mq.config, predicateAdder: mq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *MemberMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the MemberMutation builder.
+func (m *MemberMutation) Filter() *MemberFilter {
+	return &MemberFilter{config: m.config, predicateAdder: m}
+}
+
+// MemberFilter provides a generic filtering capability at runtime for MemberQuery.
+type MemberFilter struct {
+	predicateAdder
+	config
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *MemberFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[0].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql string predicate on the id field.
+func (f *MemberFilter) WhereID(p entql.StringP) {
+	f.Where(p.Field(member.FieldID))
+}
+
+// WhereName applies the entql string predicate on the name field.
+func (f *MemberFilter) WhereName(p entql.StringP) {
+	f.Where(p.Field(member.FieldName))
+}
+
+// WhereHasTeam applies a predicate to check if query has an edge team.
+func (f *MemberFilter) WhereHasTeam() {
+	f.Where(entql.HasEdge("team"))
+}
+
+// WhereHasTeamWith applies a predicate to check if query has an edge team with a given conditions (other predicates).
+func (f *MemberFilter) WhereHasTeamWith(preds ...predicate.Team) {
+	f.Where(entql.HasEdgeWith("team", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (nq *NoteQuery) addPredicate(pred func(s *sql.Selector)) {
+	nq.predicates = append(nq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the NoteQuery builder.
+func (nq *NoteQuery) Filter() *NoteFilter {
+	return &NoteFilter{config: nq.config, predicateAdder: nq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *NoteMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the NoteMutation builder.
+func (m *NoteMutation) Filter() *NoteFilter {
+	return &NoteFilter{config: m.config, predicateAdder: m}
+}
+
+// NoteFilter provides a generic filtering capability at runtime for NoteQuery.
+type NoteFilter struct {
+	predicateAdder
+	config
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *NoteFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[1].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql string predicate on the id field.
+func (f *NoteFilter) WhereID(p entql.StringP) {
+	f.Where(p.Field(note.FieldID))
+}
+
+// WhereCreatedAt applies the entql time.Time predicate on the created_at field.
+func (f *NoteFilter) WhereCreatedAt(p entql.TimeP) {
+	f.Where(p.Field(note.FieldCreatedAt))
+}
+
+// WhereUpdatedAt applies the entql time.Time predicate on the updated_at field.
+func (f *NoteFilter) WhereUpdatedAt(p entql.TimeP) {
+	f.Where(p.Field(note.FieldUpdatedAt))
+}
+
+// WhereDeletedAt applies the entql time.Time predicate on the deleted_at field.
+func (f *NoteFilter) WhereDeletedAt(p entql.TimeP) {
+	f.Where(p.Field(note.FieldDeletedAt))
+}
+
+// WhereTenantID applies the entql string predicate on the tenant_id field.
+func (f *NoteFilter) WhereTenantID(p entql.StringP) {
+	f.Where(p.Field(note.FieldTenantID))
+}
+
+// WhereVersion applies the entql int predicate on the version field.
+func (f *NoteFilter) WhereVersion(p entql.IntP) {
+	f.Where(p.Field(note.FieldVersion))
+}
+
+// WhereBody applies the entql string predicate on the body field.
+func (f *NoteFilter) WhereBody(p entql.StringP) {
+	f.Where(p.Field(note.FieldBody))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (tq *TeamQuery) addPredicate(pred func(s *sql.Selector)) {
+	tq.predicates = append(tq.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the TeamQuery builder.
+func (tq *TeamQuery) Filter() *TeamFilter {
+	return &TeamFilter{config: tq.config, predicateAdder: tq}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *TeamMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the TeamMutation builder.
+func (m *TeamMutation) Filter() *TeamFilter {
+	return &TeamFilter{config: m.config, predicateAdder: m}
+}
+
+// TeamFilter provides a generic filtering capability at runtime for TeamQuery.
+type TeamFilter struct {
+	predicateAdder
+	config
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *TeamFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[2].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql string predicate on the id field.
+func (f *TeamFilter) WhereID(p entql.StringP) {
+	f.Where(p.Field(team.FieldID))
+}
+
+// WhereName applies the entql string predicate on the name field.
+func (f *TeamFilter) WhereName(p entql.StringP) {
+	f.Where(p.Field(team.FieldName))
+}
+
+// WhereHasMembers applies a predicate to check if query has an edge members.
+func (f *TeamFilter) WhereHasMembers() {
+	f.Where(entql.HasEdge("members"))
+}
+
+// WhereHasMembersWith applies a predicate to check if query has an edge members with a given conditions (other predicates).
+func (f *TeamFilter) WhereHasMembersWith(preds ...predicate.Member) {
+	f.Where(entql.HasEdgeWith("members", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
@@ -0,0 +1,5 @@
+// Package entgen holds Ent code that is generated for testing the re-usable schema components.
+package entgen
+
+// the toolchain is pinned since the module's golang.org/x/tools can't load packages built by newer Go releases
+//go:generate env GOTOOLCHAIN=go1.23.4 go run -mod=mod entc.go
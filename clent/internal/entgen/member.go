@@ -0,0 +1,145 @@
+// Code generated by ent, DO NOT EDIT.
+
+package entgen
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/crewlinker/clgo/clent/internal/entgen/member"
+	"github.com/crewlinker/clgo/clent/internal/entgen/team"
+	"github.com/crewlinker/clgo/clid"
+)
+
+// Member is the model entity for the Member schema.
+type Member struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID clid.Native `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the MemberQuery when eager-loading is set.
+	Edges        MemberEdges `json:"edges"`
+	team_members *clid.Native
+	selectValues sql.SelectValues
+}
+
+// MemberEdges holds the relations/edges for other nodes in the graph.
+type MemberEdges struct {
+	// Team holds the value of the team edge.
+	Team *Team `json:"team,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// TeamOrErr returns the Team value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e MemberEdges) TeamOrErr() (*Team, error) {
+	if e.loadedTypes[0] {
+		if e.Team == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: team.Label}
+		}
+		return e.Team, nil
+	}
+	return nil, &NotLoadedError{edge: "team"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Member) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case member.FieldID:
+			values[i] = new(clid.Native)
+		case member.FieldName:
+			values[i] = new(sql.NullString)
+		case member.ForeignKeys[0]: // team_members
+			values[i] = &sql.NullScanner{S: new(clid.Native)}
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Member fields.
+func (m *Member) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case member.FieldID:
+			if value, ok := values[i].(*clid.Native); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				m.ID = *value
+			}
+		case member.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				m.Name = value.String
+			}
+		case member.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field team_members", values[i])
+			} else if value.Valid {
+				m.team_members = new(clid.Native)
+				*m.team_members = *value.S.(*clid.Native)
+			}
+		default:
+			m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Member.
+// This includes values selected through modifiers, order, etc.
+func (m *Member) Value(name string) (ent.Value, error) {
+	return m.selectValues.Get(name)
+}
+
+// QueryTeam queries the "team" edge of the Member entity.
+func (m *Member) QueryTeam() *TeamQuery {
+	return NewMemberClient(m.config).QueryTeam(m)
+}
+
+// Update returns a builder for updating this Member.
+// Note that you need to call Member.Unwrap() before calling this method if this Member
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (m *Member) Update() *MemberUpdateOne {
+	return NewMemberClient(m.config).UpdateOne(m)
+}
+
+// Unwrap unwraps the Member entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (m *Member) Unwrap() *Member {
+	_tx, ok := m.config.driver.(*txDriver)
+	if !ok {
+		panic("entgen: Member is not a transactional entity")
+	}
+	m.config.driver = _tx.drv
+	return m
+}
+
+// String implements the fmt.Stringer.
+func (m *Member) String() string {
+	var builder strings.Builder
+	builder.WriteString("Member(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", m.ID))
+	builder.WriteString("name=")
+	builder.WriteString(m.Name)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Members is a parsable slice of Member.
+type Members []*Member
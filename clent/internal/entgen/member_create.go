@@ -0,0 +1,255 @@
+// Code generated by ent, DO NOT EDIT.
+
+package entgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/crewlinker/clgo/clent/internal/entgen/member"
+	"github.com/crewlinker/clgo/clent/internal/entgen/team"
+	"github.com/crewlinker/clgo/clid"
+)
+
+// MemberCreate is the builder for creating a Member entity.
+type MemberCreate struct {
+	config
+	mutation *MemberMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (mc *MemberCreate) SetName(s string) *MemberCreate {
+	mc.mutation.SetName(s)
+	return mc
+}
+
+// SetID sets the "id" field.
+func (mc *MemberCreate) SetID(c clid.Native) *MemberCreate {
+	mc.mutation.SetID(c)
+	return mc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (mc *MemberCreate) SetNillableID(c *clid.Native) *MemberCreate {
+	if c != nil {
+		mc.SetID(*c)
+	}
+	return mc
+}
+
+// SetTeamID sets the "team" edge to the Team entity by ID.
+func (mc *MemberCreate) SetTeamID(id clid.Native) *MemberCreate {
+	mc.mutation.SetTeamID(id)
+	return mc
+}
+
+// SetNillableTeamID sets the "team" edge to the Team entity by ID if the given value is not nil.
+func (mc *MemberCreate) SetNillableTeamID(id *clid.Native) *MemberCreate {
+	if id != nil {
+		mc = mc.SetTeamID(*id)
+	}
+	return mc
+}
+
+// SetTeam sets the "team" edge to the Team entity.
+func (mc *MemberCreate) SetTeam(t *Team) *MemberCreate {
+	return mc.SetTeamID(t.ID)
+}
+
+// Mutation returns the MemberMutation object of the builder.
+func (mc *MemberCreate) Mutation() *MemberMutation {
+	return mc.mutation
+}
+
+// Save creates the Member in the database.
+func (mc *MemberCreate) Save(ctx context.Context) (*Member, error) {
+	mc.defaults()
+	return withHooks(ctx, mc.sqlSave, mc.mutation, mc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (mc *MemberCreate) SaveX(ctx context.Context) *Member {
+	v, err := mc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (mc *MemberCreate) Exec(ctx context.Context) error {
+	_, err := mc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mc *MemberCreate) ExecX(ctx context.Context) {
+	if err := mc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (mc *MemberCreate) defaults() {
+	if _, ok := mc.mutation.ID(); !ok {
+		v := member.DefaultID()
+		mc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (mc *MemberCreate) check() error {
+	if _, ok := mc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`entgen: missing required field "Member.name"`)}
+	}
+	if v, ok := mc.mutation.ID(); ok {
+		if err := member.IDValidator(v.String()); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`entgen: validator failed for field "Member.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (mc *MemberCreate) sqlSave(ctx context.Context) (*Member, error) {
+	if err := mc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := mc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, mc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*clid.Native); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	mc.mutation.id = &_node.ID
+	mc.mutation.done = true
+	return _node, nil
+}
+
+func (mc *MemberCreate) createSpec() (*Member, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Member{config: mc.config}
+		_spec = sqlgraph.NewCreateSpec(member.Table, sqlgraph.NewFieldSpec(member.FieldID, field.TypeString))
+	)
+	if id, ok := mc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := mc.mutation.Name(); ok {
+		_spec.SetField(member.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if nodes := mc.mutation.TeamIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   member.TeamTable,
+			Columns: []string{member.TeamColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.team_members = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// MemberCreateBulk is the builder for creating many Member entities in bulk.
+type MemberCreateBulk struct {
+	config
+	err      error
+	builders []*MemberCreate
+}
+
+// Save creates the Member entities in the database.
+func (mcb *MemberCreateBulk) Save(ctx context.Context) ([]*Member, error) {
+	if mcb.err != nil {
+		return nil, mcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(mcb.builders))
+	nodes := make([]*Member, len(mcb.builders))
+	mutators := make([]Mutator, len(mcb.builders))
+	for i := range mcb.builders {
+		func(i int, root context.Context) {
+			builder := mcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*MemberMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, mcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, mcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, mcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (mcb *MemberCreateBulk) SaveX(ctx context.Context) []*Member {
+	v, err := mcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (mcb *MemberCreateBulk) Exec(ctx context.Context) error {
+	_, err := mcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mcb *MemberCreateBulk) ExecX(ctx context.Context) {
+	if err := mcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,319 @@
+// Code generated by ent, DO NOT EDIT.
+
+package entgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/crewlinker/clgo/clent/internal/entgen/member"
+	"github.com/crewlinker/clgo/clent/internal/entgen/predicate"
+	"github.com/crewlinker/clgo/clent/internal/entgen/team"
+	"github.com/crewlinker/clgo/clid"
+)
+
+// MemberUpdate is the builder for updating Member entities.
+type MemberUpdate struct {
+	config
+	hooks    []Hook
+	mutation *MemberMutation
+}
+
+// Where appends a list predicates to the MemberUpdate builder.
+func (mu *MemberUpdate) Where(ps ...predicate.Member) *MemberUpdate {
+	mu.mutation.Where(ps...)
+	return mu
+}
+
+// SetName sets the "name" field.
+func (mu *MemberUpdate) SetName(s string) *MemberUpdate {
+	mu.mutation.SetName(s)
+	return mu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (mu *MemberUpdate) SetNillableName(s *string) *MemberUpdate {
+	if s != nil {
+		mu.SetName(*s)
+	}
+	return mu
+}
+
+// SetTeamID sets the "team" edge to the Team entity by ID.
+func (mu *MemberUpdate) SetTeamID(id clid.Native) *MemberUpdate {
+	mu.mutation.SetTeamID(id)
+	return mu
+}
+
+// SetNillableTeamID sets the "team" edge to the Team entity by ID if the given value is not nil.
+func (mu *MemberUpdate) SetNillableTeamID(id *clid.Native) *MemberUpdate {
+	if id != nil {
+		mu = mu.SetTeamID(*id)
+	}
+	return mu
+}
+
+// SetTeam sets the "team" edge to the Team entity.
+func (mu *MemberUpdate) SetTeam(t *Team) *MemberUpdate {
+	return mu.SetTeamID(t.ID)
+}
+
+// Mutation returns the MemberMutation object of the builder.
+func (mu *MemberUpdate) Mutation() *MemberMutation {
+	return mu.mutation
+}
+
+// ClearTeam clears the "team" edge to the Team entity.
+func (mu *MemberUpdate) ClearTeam() *MemberUpdate {
+	mu.mutation.ClearTeam()
+	return mu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (mu *MemberUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, mu.sqlSave, mu.mutation, mu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (mu *MemberUpdate) SaveX(ctx context.Context) int {
+	affected, err := mu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (mu *MemberUpdate) Exec(ctx context.Context) error {
+	_, err := mu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mu *MemberUpdate) ExecX(ctx context.Context) {
+	if err := mu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (mu *MemberUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(member.Table, member.Columns, sqlgraph.NewFieldSpec(member.FieldID, field.TypeString))
+	if ps := mu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := mu.mutation.Name(); ok {
+		_spec.SetField(member.FieldName, field.TypeString, value)
+	}
+	if mu.mutation.TeamCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   member.TeamTable,
+			Columns: []string{member.TeamColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mu.mutation.TeamIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   member.TeamTable,
+			Columns: []string{member.TeamColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, mu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{member.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	mu.mutation.done = true
+	return n, nil
+}
+
+// MemberUpdateOne is the builder for updating a single Member entity.
+type MemberUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *MemberMutation
+}
+
+// SetName sets the "name" field.
+func (muo *MemberUpdateOne) SetName(s string) *MemberUpdateOne {
+	muo.mutation.SetName(s)
+	return muo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (muo *MemberUpdateOne) SetNillableName(s *string) *MemberUpdateOne {
+	if s != nil {
+		muo.SetName(*s)
+	}
+	return muo
+}
+
+// SetTeamID sets the "team" edge to the Team entity by ID.
+func (muo *MemberUpdateOne) SetTeamID(id clid.Native) *MemberUpdateOne {
+	muo.mutation.SetTeamID(id)
+	return muo
+}
+
+// SetNillableTeamID sets the "team" edge to the Team entity by ID if the given value is not nil.
+func (muo *MemberUpdateOne) SetNillableTeamID(id *clid.Native) *MemberUpdateOne {
+	if id != nil {
+		muo = muo.SetTeamID(*id)
+	}
+	return muo
+}
+
+// SetTeam sets the "team" edge to the Team entity.
+func (muo *MemberUpdateOne) SetTeam(t *Team) *MemberUpdateOne {
+	return muo.SetTeamID(t.ID)
+}
+
+// Mutation returns the MemberMutation object of the builder.
+func (muo *MemberUpdateOne) Mutation() *MemberMutation {
+	return muo.mutation
+}
+
+// ClearTeam clears the "team" edge to the Team entity.
+func (muo *MemberUpdateOne) ClearTeam() *MemberUpdateOne {
+	muo.mutation.ClearTeam()
+	return muo
+}
+
+// Where appends a list predicates to the MemberUpdate builder.
+func (muo *MemberUpdateOne) Where(ps ...predicate.Member) *MemberUpdateOne {
+	muo.mutation.Where(ps...)
+	return muo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (muo *MemberUpdateOne) Select(field string, fields ...string) *MemberUpdateOne {
+	muo.fields = append([]string{field}, fields...)
+	return muo
+}
+
+// Save executes the query and returns the updated Member entity.
+func (muo *MemberUpdateOne) Save(ctx context.Context) (*Member, error) {
+	return withHooks(ctx, muo.sqlSave, muo.mutation, muo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (muo *MemberUpdateOne) SaveX(ctx context.Context) *Member {
+	node, err := muo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (muo *MemberUpdateOne) Exec(ctx context.Context) error {
+	_, err := muo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (muo *MemberUpdateOne) ExecX(ctx context.Context) {
+	if err := muo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (muo *MemberUpdateOne) sqlSave(ctx context.Context) (_node *Member, err error) {
+	_spec := sqlgraph.NewUpdateSpec(member.Table, member.Columns, sqlgraph.NewFieldSpec(member.FieldID, field.TypeString))
+	id, ok := muo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`entgen: missing "Member.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := muo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, member.FieldID)
+		for _, f := range fields {
+			if !member.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("entgen: invalid field %q for query", f)}
+			}
+			if f != member.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := muo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := muo.mutation.Name(); ok {
+		_spec.SetField(member.FieldName, field.TypeString, value)
+	}
+	if muo.mutation.TeamCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   member.TeamTable,
+			Columns: []string{member.TeamColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := muo.mutation.TeamIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   member.TeamTable,
+			Columns: []string{member.TeamColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Member{config: muo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, muo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{member.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	muo.mutation.done = true
+	return _node, nil
+}
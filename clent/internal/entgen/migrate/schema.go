@@ -0,0 +1,77 @@
+// Code generated by ent, DO NOT EDIT.
+
+package migrate
+
+import (
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/schema/field"
+)
+
+var (
+	// MembersColumns holds the columns for the "members" table.
+	MembersColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, SchemaType: map[string]string{"postgres": "clid"}},
+		{Name: "name", Type: field.TypeString},
+		{Name: "team_members", Type: field.TypeString, Nullable: true, SchemaType: map[string]string{"postgres": "clid"}},
+	}
+	// MembersTable holds the schema information for the "members" table.
+	MembersTable = &schema.Table{
+		Name:       "members",
+		Columns:    MembersColumns,
+		PrimaryKey: []*schema.Column{MembersColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "members_teams_members",
+				Columns:    []*schema.Column{MembersColumns[2]},
+				RefColumns: []*schema.Column{TeamsColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+	}
+	// NotesColumns holds the columns for the "notes" table.
+	NotesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, SchemaType: map[string]string{"mysql": "varchar(31)", "postgres": "varchar(31)"}},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+		{Name: "updated_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
+		{Name: "tenant_id", Type: field.TypeString, SchemaType: map[string]string{"mysql": "varchar(31)", "postgres": "varchar(31)"}},
+		{Name: "version", Type: field.TypeInt, Default: "0"},
+		{Name: "body", Type: field.TypeString},
+	}
+	// NotesTable holds the schema information for the "notes" table.
+	NotesTable = &schema.Table{
+		Name:       "notes",
+		Columns:    NotesColumns,
+		PrimaryKey: []*schema.Column{NotesColumns[0]},
+	}
+	// TeamsColumns holds the columns for the "teams" table.
+	TeamsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, SchemaType: map[string]string{"postgres": "clid"}},
+		{Name: "name", Type: field.TypeString},
+	}
+	// TeamsTable holds the schema information for the "teams" table.
+	TeamsTable = &schema.Table{
+		Name:       "teams",
+		Columns:    TeamsColumns,
+		PrimaryKey: []*schema.Column{TeamsColumns[0]},
+	}
+	// Tables holds all the tables in the schema.
+	Tables = []*schema.Table{
+		MembersTable,
+		NotesTable,
+		TeamsTable,
+	}
+)
+
+func init() {
+	MembersTable.ForeignKeys[0].RefTable = TeamsTable
+	MembersTable.Annotation = &entsql.Annotation{}
+	MembersTable.Annotation.Checks = map[string]string{
+		"id_clid_prefix": "(\"id\").prefix = 'mmbr'",
+	}
+	TeamsTable.Annotation = &entsql.Annotation{}
+	TeamsTable.Annotation.Checks = map[string]string{
+		"id_clid_prefix": "(\"id\").prefix = 'team'",
+	}
+}
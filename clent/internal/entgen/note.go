@@ -0,0 +1,167 @@
+// Code generated by ent, DO NOT EDIT.
+
+package entgen
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/crewlinker/clgo/clent/internal/entgen/note"
+	"github.com/crewlinker/clgo/clid"
+)
+
+// Note is the model entity for the Note schema.
+type Note struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID clid.ID `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// TenantID holds the value of the "tenant_id" field.
+	TenantID clid.ID `json:"tenant_id,omitempty"`
+	// Version holds the value of the "version" field.
+	Version int `json:"version,omitempty"`
+	// Body holds the value of the "body" field.
+	Body         string `json:"body,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Note) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case note.FieldID, note.FieldTenantID:
+			values[i] = new(clid.ID)
+		case note.FieldVersion:
+			values[i] = new(sql.NullInt64)
+		case note.FieldBody:
+			values[i] = new(sql.NullString)
+		case note.FieldCreatedAt, note.FieldUpdatedAt, note.FieldDeletedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Note fields.
+func (n *Note) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case note.FieldID:
+			if value, ok := values[i].(*clid.ID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				n.ID = *value
+			}
+		case note.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				n.CreatedAt = value.Time
+			}
+		case note.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				n.UpdatedAt = value.Time
+			}
+		case note.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
+			} else if value.Valid {
+				n.DeletedAt = new(time.Time)
+				*n.DeletedAt = value.Time
+			}
+		case note.FieldTenantID:
+			if value, ok := values[i].(*clid.ID); !ok {
+				return fmt.Errorf("unexpected type %T for field tenant_id", values[i])
+			} else if value != nil {
+				n.TenantID = *value
+			}
+		case note.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				n.Version = int(value.Int64)
+			}
+		case note.FieldBody:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field body", values[i])
+			} else if value.Valid {
+				n.Body = value.String
+			}
+		default:
+			n.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Note.
+// This includes values selected through modifiers, order, etc.
+func (n *Note) Value(name string) (ent.Value, error) {
+	return n.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Note.
+// Note that you need to call Note.Unwrap() before calling this method if this Note
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (n *Note) Update() *NoteUpdateOne {
+	return NewNoteClient(n.config).UpdateOne(n)
+}
+
+// Unwrap unwraps the Note entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (n *Note) Unwrap() *Note {
+	_tx, ok := n.config.driver.(*txDriver)
+	if !ok {
+		panic("entgen: Note is not a transactional entity")
+	}
+	n.config.driver = _tx.drv
+	return n
+}
+
+// String implements the fmt.Stringer.
+func (n *Note) String() string {
+	var builder strings.Builder
+	builder.WriteString("Note(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", n.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(n.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(n.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := n.DeletedAt; v != nil {
+		builder.WriteString("deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("tenant_id=")
+	builder.WriteString(fmt.Sprintf("%v", n.TenantID))
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", n.Version))
+	builder.WriteString(", ")
+	builder.WriteString("body=")
+	builder.WriteString(n.Body)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Notes is a parsable slice of Note.
+type Notes []*Note
@@ -0,0 +1,342 @@
+// Code generated by ent, DO NOT EDIT.
+
+package entgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/crewlinker/clgo/clent/internal/entgen/note"
+	"github.com/crewlinker/clgo/clid"
+)
+
+// NoteCreate is the builder for creating a Note entity.
+type NoteCreate struct {
+	config
+	mutation *NoteMutation
+	hooks    []Hook
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (nc *NoteCreate) SetCreatedAt(t time.Time) *NoteCreate {
+	nc.mutation.SetCreatedAt(t)
+	return nc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (nc *NoteCreate) SetNillableCreatedAt(t *time.Time) *NoteCreate {
+	if t != nil {
+		nc.SetCreatedAt(*t)
+	}
+	return nc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (nc *NoteCreate) SetUpdatedAt(t time.Time) *NoteCreate {
+	nc.mutation.SetUpdatedAt(t)
+	return nc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (nc *NoteCreate) SetNillableUpdatedAt(t *time.Time) *NoteCreate {
+	if t != nil {
+		nc.SetUpdatedAt(*t)
+	}
+	return nc
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (nc *NoteCreate) SetDeletedAt(t time.Time) *NoteCreate {
+	nc.mutation.SetDeletedAt(t)
+	return nc
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (nc *NoteCreate) SetNillableDeletedAt(t *time.Time) *NoteCreate {
+	if t != nil {
+		nc.SetDeletedAt(*t)
+	}
+	return nc
+}
+
+// SetTenantID sets the "tenant_id" field.
+func (nc *NoteCreate) SetTenantID(c clid.ID) *NoteCreate {
+	nc.mutation.SetTenantID(c)
+	return nc
+}
+
+// SetVersion sets the "version" field.
+func (nc *NoteCreate) SetVersion(i int) *NoteCreate {
+	nc.mutation.SetVersion(i)
+	return nc
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (nc *NoteCreate) SetNillableVersion(i *int) *NoteCreate {
+	if i != nil {
+		nc.SetVersion(*i)
+	}
+	return nc
+}
+
+// SetBody sets the "body" field.
+func (nc *NoteCreate) SetBody(s string) *NoteCreate {
+	nc.mutation.SetBody(s)
+	return nc
+}
+
+// SetID sets the "id" field.
+func (nc *NoteCreate) SetID(c clid.ID) *NoteCreate {
+	nc.mutation.SetID(c)
+	return nc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (nc *NoteCreate) SetNillableID(c *clid.ID) *NoteCreate {
+	if c != nil {
+		nc.SetID(*c)
+	}
+	return nc
+}
+
+// Mutation returns the NoteMutation object of the builder.
+func (nc *NoteCreate) Mutation() *NoteMutation {
+	return nc.mutation
+}
+
+// Save creates the Note in the database.
+func (nc *NoteCreate) Save(ctx context.Context) (*Note, error) {
+	if err := nc.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, nc.sqlSave, nc.mutation, nc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (nc *NoteCreate) SaveX(ctx context.Context) *Note {
+	v, err := nc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (nc *NoteCreate) Exec(ctx context.Context) error {
+	_, err := nc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (nc *NoteCreate) ExecX(ctx context.Context) {
+	if err := nc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (nc *NoteCreate) defaults() error {
+	if _, ok := nc.mutation.CreatedAt(); !ok {
+		if note.DefaultCreatedAt == nil {
+			return fmt.Errorf("entgen: uninitialized note.DefaultCreatedAt (forgotten import entgen/runtime?)")
+		}
+		v := note.DefaultCreatedAt()
+		nc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := nc.mutation.UpdatedAt(); !ok {
+		if note.DefaultUpdatedAt == nil {
+			return fmt.Errorf("entgen: uninitialized note.DefaultUpdatedAt (forgotten import entgen/runtime?)")
+		}
+		v := note.DefaultUpdatedAt()
+		nc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := nc.mutation.Version(); !ok {
+		v := note.DefaultVersion
+		nc.mutation.SetVersion(v)
+	}
+	if _, ok := nc.mutation.ID(); !ok {
+		if note.DefaultID == nil {
+			return fmt.Errorf("entgen: uninitialized note.DefaultID (forgotten import entgen/runtime?)")
+		}
+		v := note.DefaultID()
+		nc.mutation.SetID(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (nc *NoteCreate) check() error {
+	if _, ok := nc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`entgen: missing required field "Note.created_at"`)}
+	}
+	if _, ok := nc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`entgen: missing required field "Note.updated_at"`)}
+	}
+	if _, ok := nc.mutation.TenantID(); !ok {
+		return &ValidationError{Name: "tenant_id", err: errors.New(`entgen: missing required field "Note.tenant_id"`)}
+	}
+	if v, ok := nc.mutation.TenantID(); ok {
+		if err := note.TenantIDValidator(v.String()); err != nil {
+			return &ValidationError{Name: "tenant_id", err: fmt.Errorf(`entgen: validator failed for field "Note.tenant_id": %w`, err)}
+		}
+	}
+	if _, ok := nc.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`entgen: missing required field "Note.version"`)}
+	}
+	if _, ok := nc.mutation.Body(); !ok {
+		return &ValidationError{Name: "body", err: errors.New(`entgen: missing required field "Note.body"`)}
+	}
+	if v, ok := nc.mutation.ID(); ok {
+		if err := note.IDValidator(v.String()); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`entgen: validator failed for field "Note.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (nc *NoteCreate) sqlSave(ctx context.Context) (*Note, error) {
+	if err := nc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := nc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, nc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*clid.ID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	nc.mutation.id = &_node.ID
+	nc.mutation.done = true
+	return _node, nil
+}
+
+func (nc *NoteCreate) createSpec() (*Note, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Note{config: nc.config}
+		_spec = sqlgraph.NewCreateSpec(note.Table, sqlgraph.NewFieldSpec(note.FieldID, field.TypeString))
+	)
+	if id, ok := nc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := nc.mutation.CreatedAt(); ok {
+		_spec.SetField(note.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := nc.mutation.UpdatedAt(); ok {
+		_spec.SetField(note.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := nc.mutation.DeletedAt(); ok {
+		_spec.SetField(note.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = &value
+	}
+	if value, ok := nc.mutation.TenantID(); ok {
+		_spec.SetField(note.FieldTenantID, field.TypeString, value)
+		_node.TenantID = value
+	}
+	if value, ok := nc.mutation.Version(); ok {
+		_spec.SetField(note.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := nc.mutation.Body(); ok {
+		_spec.SetField(note.FieldBody, field.TypeString, value)
+		_node.Body = value
+	}
+	return _node, _spec
+}
+
+// NoteCreateBulk is the builder for creating many Note entities in bulk.
+type NoteCreateBulk struct {
+	config
+	err      error
+	builders []*NoteCreate
+}
+
+// Save creates the Note entities in the database.
+func (ncb *NoteCreateBulk) Save(ctx context.Context) ([]*Note, error) {
+	if ncb.err != nil {
+		return nil, ncb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ncb.builders))
+	nodes := make([]*Note, len(ncb.builders))
+	mutators := make([]Mutator, len(ncb.builders))
+	for i := range ncb.builders {
+		func(i int, root context.Context) {
+			builder := ncb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*NoteMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ncb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ncb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ncb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ncb *NoteCreateBulk) SaveX(ctx context.Context) []*Note {
+	v, err := ncb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ncb *NoteCreateBulk) Exec(ctx context.Context) error {
+	_, err := ncb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ncb *NoteCreateBulk) ExecX(ctx context.Context) {
+	if err := ncb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
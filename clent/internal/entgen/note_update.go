@@ -0,0 +1,364 @@
+// Code generated by ent, DO NOT EDIT.
+
+package entgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/crewlinker/clgo/clent/internal/entgen/note"
+	"github.com/crewlinker/clgo/clent/internal/entgen/predicate"
+)
+
+// NoteUpdate is the builder for updating Note entities.
+type NoteUpdate struct {
+	config
+	hooks    []Hook
+	mutation *NoteMutation
+}
+
+// Where appends a list predicates to the NoteUpdate builder.
+func (nu *NoteUpdate) Where(ps ...predicate.Note) *NoteUpdate {
+	nu.mutation.Where(ps...)
+	return nu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (nu *NoteUpdate) SetUpdatedAt(t time.Time) *NoteUpdate {
+	nu.mutation.SetUpdatedAt(t)
+	return nu
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (nu *NoteUpdate) SetDeletedAt(t time.Time) *NoteUpdate {
+	nu.mutation.SetDeletedAt(t)
+	return nu
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (nu *NoteUpdate) SetNillableDeletedAt(t *time.Time) *NoteUpdate {
+	if t != nil {
+		nu.SetDeletedAt(*t)
+	}
+	return nu
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (nu *NoteUpdate) ClearDeletedAt() *NoteUpdate {
+	nu.mutation.ClearDeletedAt()
+	return nu
+}
+
+// SetVersion sets the "version" field.
+func (nu *NoteUpdate) SetVersion(i int) *NoteUpdate {
+	nu.mutation.ResetVersion()
+	nu.mutation.SetVersion(i)
+	return nu
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (nu *NoteUpdate) SetNillableVersion(i *int) *NoteUpdate {
+	if i != nil {
+		nu.SetVersion(*i)
+	}
+	return nu
+}
+
+// AddVersion adds i to the "version" field.
+func (nu *NoteUpdate) AddVersion(i int) *NoteUpdate {
+	nu.mutation.AddVersion(i)
+	return nu
+}
+
+// SetBody sets the "body" field.
+func (nu *NoteUpdate) SetBody(s string) *NoteUpdate {
+	nu.mutation.SetBody(s)
+	return nu
+}
+
+// SetNillableBody sets the "body" field if the given value is not nil.
+func (nu *NoteUpdate) SetNillableBody(s *string) *NoteUpdate {
+	if s != nil {
+		nu.SetBody(*s)
+	}
+	return nu
+}
+
+// Mutation returns the NoteMutation object of the builder.
+func (nu *NoteUpdate) Mutation() *NoteMutation {
+	return nu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (nu *NoteUpdate) Save(ctx context.Context) (int, error) {
+	if err := nu.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, nu.sqlSave, nu.mutation, nu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (nu *NoteUpdate) SaveX(ctx context.Context) int {
+	affected, err := nu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (nu *NoteUpdate) Exec(ctx context.Context) error {
+	_, err := nu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (nu *NoteUpdate) ExecX(ctx context.Context) {
+	if err := nu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (nu *NoteUpdate) defaults() error {
+	if _, ok := nu.mutation.UpdatedAt(); !ok {
+		if note.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("entgen: uninitialized note.UpdateDefaultUpdatedAt (forgotten import entgen/runtime?)")
+		}
+		v := note.UpdateDefaultUpdatedAt()
+		nu.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+func (nu *NoteUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(note.Table, note.Columns, sqlgraph.NewFieldSpec(note.FieldID, field.TypeString))
+	if ps := nu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := nu.mutation.UpdatedAt(); ok {
+		_spec.SetField(note.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := nu.mutation.DeletedAt(); ok {
+		_spec.SetField(note.FieldDeletedAt, field.TypeTime, value)
+	}
+	if nu.mutation.DeletedAtCleared() {
+		_spec.ClearField(note.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := nu.mutation.Version(); ok {
+		_spec.SetField(note.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := nu.mutation.AddedVersion(); ok {
+		_spec.AddField(note.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := nu.mutation.Body(); ok {
+		_spec.SetField(note.FieldBody, field.TypeString, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, nu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{note.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	nu.mutation.done = true
+	return n, nil
+}
+
+// NoteUpdateOne is the builder for updating a single Note entity.
+type NoteUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *NoteMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (nuo *NoteUpdateOne) SetUpdatedAt(t time.Time) *NoteUpdateOne {
+	nuo.mutation.SetUpdatedAt(t)
+	return nuo
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (nuo *NoteUpdateOne) SetDeletedAt(t time.Time) *NoteUpdateOne {
+	nuo.mutation.SetDeletedAt(t)
+	return nuo
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (nuo *NoteUpdateOne) SetNillableDeletedAt(t *time.Time) *NoteUpdateOne {
+	if t != nil {
+		nuo.SetDeletedAt(*t)
+	}
+	return nuo
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (nuo *NoteUpdateOne) ClearDeletedAt() *NoteUpdateOne {
+	nuo.mutation.ClearDeletedAt()
+	return nuo
+}
+
+// SetVersion sets the "version" field.
+func (nuo *NoteUpdateOne) SetVersion(i int) *NoteUpdateOne {
+	nuo.mutation.ResetVersion()
+	nuo.mutation.SetVersion(i)
+	return nuo
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (nuo *NoteUpdateOne) SetNillableVersion(i *int) *NoteUpdateOne {
+	if i != nil {
+		nuo.SetVersion(*i)
+	}
+	return nuo
+}
+
+// AddVersion adds i to the "version" field.
+func (nuo *NoteUpdateOne) AddVersion(i int) *NoteUpdateOne {
+	nuo.mutation.AddVersion(i)
+	return nuo
+}
+
+// SetBody sets the "body" field.
+func (nuo *NoteUpdateOne) SetBody(s string) *NoteUpdateOne {
+	nuo.mutation.SetBody(s)
+	return nuo
+}
+
+// SetNillableBody sets the "body" field if the given value is not nil.
+func (nuo *NoteUpdateOne) SetNillableBody(s *string) *NoteUpdateOne {
+	if s != nil {
+		nuo.SetBody(*s)
+	}
+	return nuo
+}
+
+// Mutation returns the NoteMutation object of the builder.
+func (nuo *NoteUpdateOne) Mutation() *NoteMutation {
+	return nuo.mutation
+}
+
+// Where appends a list predicates to the NoteUpdate builder.
+func (nuo *NoteUpdateOne) Where(ps ...predicate.Note) *NoteUpdateOne {
+	nuo.mutation.Where(ps...)
+	return nuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (nuo *NoteUpdateOne) Select(field string, fields ...string) *NoteUpdateOne {
+	nuo.fields = append([]string{field}, fields...)
+	return nuo
+}
+
+// Save executes the query and returns the updated Note entity.
+func (nuo *NoteUpdateOne) Save(ctx context.Context) (*Note, error) {
+	if err := nuo.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, nuo.sqlSave, nuo.mutation, nuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (nuo *NoteUpdateOne) SaveX(ctx context.Context) *Note {
+	node, err := nuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (nuo *NoteUpdateOne) Exec(ctx context.Context) error {
+	_, err := nuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (nuo *NoteUpdateOne) ExecX(ctx context.Context) {
+	if err := nuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (nuo *NoteUpdateOne) defaults() error {
+	if _, ok := nuo.mutation.UpdatedAt(); !ok {
+		if note.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("entgen: uninitialized note.UpdateDefaultUpdatedAt (forgotten import entgen/runtime?)")
+		}
+		v := note.UpdateDefaultUpdatedAt()
+		nuo.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+func (nuo *NoteUpdateOne) sqlSave(ctx context.Context) (_node *Note, err error) {
+	_spec := sqlgraph.NewUpdateSpec(note.Table, note.Columns, sqlgraph.NewFieldSpec(note.FieldID, field.TypeString))
+	id, ok := nuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`entgen: missing "Note.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := nuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, note.FieldID)
+		for _, f := range fields {
+			if !note.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("entgen: invalid field %q for query", f)}
+			}
+			if f != note.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := nuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := nuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(note.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := nuo.mutation.DeletedAt(); ok {
+		_spec.SetField(note.FieldDeletedAt, field.TypeTime, value)
+	}
+	if nuo.mutation.DeletedAtCleared() {
+		_spec.ClearField(note.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := nuo.mutation.Version(); ok {
+		_spec.SetField(note.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := nuo.mutation.AddedVersion(); ok {
+		_spec.AddField(note.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := nuo.mutation.Body(); ok {
+		_spec.SetField(note.FieldBody, field.TypeString, value)
+	}
+	_node = &Note{config: nuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, nuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{note.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	nuo.mutation.done = true
+	return _node, nil
+}
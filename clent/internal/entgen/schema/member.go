@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/crewlinker/clgo/clent"
+)
+
+// Member holds the schema definition for the Member entity, its edge to the team is stored as a native clid.
+type Member struct {
+	ent.Schema
+}
+
+// Fields of the Member.
+func (Member) Fields() []ent.Field {
+	return []ent.Field{
+		clent.Field.CLIDNative("id", "mmbr"),
+		field.String("name"),
+	}
+}
+
+// Edges of the Member.
+func (Member) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("team", Team.Type).Ref("members").Unique(),
+	}
+}
+
+// Annotations of the Member.
+func (Member) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		clent.CLIDNativeCheck("id", "mmbr"),
+	}
+}
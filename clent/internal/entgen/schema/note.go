@@ -0,0 +1,33 @@
+// Package schema describes the Ent schema for testing.
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/crewlinker/clgo/clconnect"
+	"github.com/crewlinker/clgo/clent"
+	"github.com/crewlinker/clgo/clent/internal/entgen"
+)
+
+// Note holds the schema definition for the Note entity.
+type Note struct {
+	ent.Schema
+}
+
+// Mixin of the Note.
+func (Note) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		clent.TimeMixin{},
+		clent.SoftDeleteMixin[*entgen.Client]{},
+		clent.TenantMixin{Prefix: "tent", Resolver: clconnect.NewOpenIDTenantResolver("tid")},
+		clent.VersionMixin{},
+	}
+}
+
+// Fields of the Note.
+func (Note) Fields() []ent.Field {
+	return []ent.Field{
+		clent.Field.CLID("id", "note"),
+		field.String("body"),
+	}
+}
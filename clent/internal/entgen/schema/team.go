@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/crewlinker/clgo/clent"
+)
+
+// Team holds the schema definition for the Team entity, it is identified by a native clid.
+type Team struct {
+	ent.Schema
+}
+
+// Fields of the Team.
+func (Team) Fields() []ent.Field {
+	return []ent.Field{
+		clent.Field.CLIDNative("id", "team"),
+		field.String("name"),
+	}
+}
+
+// Edges of the Team.
+func (Team) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("members", Member.Type),
+	}
+}
+
+// Annotations of the Team.
+func (Team) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		clent.CLIDNativeCheck("id", "team"),
+	}
+}
@@ -0,0 +1,98 @@
+package clent
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+	"github.com/crewlinker/clgo/clent/clenthook"
+	"github.com/crewlinker/clgo/cltenant"
+)
+
+// TimeMixin adds created_at and updated_at fields that default to the current time, both in Go
+// and in the database.
+type TimeMixin struct{ mixin.Schema }
+
+// Fields of the mixin.
+func (TimeMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now).
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+	}
+}
+
+// SoftDeleteMixin adds a deleted_at field, turns deletes into updates of that field and filters out soft
+// deleted entities from queries. Use clenthook.SkipSoftDelete to query or delete them regardless. The type
+// parameter must be the generated client, e.g: SoftDeleteMixin[*ent.Client]{}.
+type SoftDeleteMixin[C clenthook.Mutater] struct{ mixin.Schema }
+
+// Fields of the mixin.
+func (SoftDeleteMixin[C]) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time(clenthook.DeletedAtField).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Hooks of the mixin.
+func (SoftDeleteMixin[C]) Hooks() []ent.Hook {
+	return []ent.Hook{clenthook.SoftDelete[C]()}
+}
+
+// Interceptors of the mixin.
+func (SoftDeleteMixin[C]) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{clenthook.SoftDeleteFilter()}
+}
+
+// TenantMixin adds an immutable tenant_id field and a privacy policy that scopes all queries and mutations
+// to the tenant that is resolved from the context. Requires the Ent "privacy" feature to be enabled.
+type TenantMixin struct {
+	mixin.Schema
+	// Prefix of the tenant's clid.
+	Prefix string
+	// Resolver resolves the tenant from the context.
+	Resolver cltenant.Resolver
+}
+
+// Fields of the mixin.
+func (m TenantMixin) Fields() []ent.Field {
+	// the tenant is set by the policy, so unlike other clid fields it should never default to a new id.
+	tid := Field.CLID("tenant_id", m.Prefix)
+	tid.Descriptor().Immutable = true
+	tid.Descriptor().Default = nil
+
+	return []ent.Field{tid}
+}
+
+// Policy of the mixin.
+func (m TenantMixin) Policy() ent.Policy {
+	return clenthook.TenantPolicy("tenant_id", m.Resolver)
+}
+
+// VersionMixin adds a version field that is incremented on every update. Setting the version explicitly
+// on an update enables optimistic locking: the update fails with clenthook.ErrVersionMismatch when the
+// entity's version is different.
+type VersionMixin struct{ mixin.Schema }
+
+// Fields of the mixin.
+func (VersionMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("version").
+			Default(0).
+			Annotations(entsql.Default("0")),
+	}
+}
+
+// Hooks of the mixin.
+func (VersionMixin) Hooks() []ent.Hook {
+	return []ent.Hook{clenthook.OptimisticLock("version")}
+}
@@ -0,0 +1,136 @@
+package clent_test
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+
+	"ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/privacy"
+	"github.com/crewlinker/clgo/clconnect"
+	"github.com/crewlinker/clgo/clent"
+	"github.com/crewlinker/clgo/clent/clenthook"
+	"github.com/crewlinker/clgo/clent/internal/entgen"
+	"github.com/crewlinker/clgo/clent/internal/entgen/note"
+	_ "github.com/crewlinker/clgo/clent/internal/entgen/runtime"
+	"github.com/crewlinker/clgo/clid"
+	"github.com/crewlinker/clgo/clpostgres"
+	"github.com/crewlinker/clgo/clpostgres/clpgmigrate"
+	"github.com/crewlinker/clgo/clzap"
+	"github.com/lestrrat-go/jwx/v2/jwt/openid"
+	"github.com/samber/lo"
+	"go.uber.org/fx"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+//go:embed testdata/migrations/*.sql
+var migrations embed.FS
+
+var _ = Describe("mixins", func() {
+	var client *entgen.Client
+	var tnt1, tnt2 context.Context
+
+	BeforeEach(func(ctx context.Context) {
+		app := fx.New(
+			fx.Populate(fx.Annotate(&client, fx.ParamTags(`name:"rw"`))),
+			fx.Supply(clent.ConstructClient[*entgen.Client](func(d dialect.Driver) *entgen.Client {
+				return entgen.NewClient(entgen.Driver(d))
+			})),
+			clent.Provide[*entgen.Client](),
+			clzap.TestProvide(),
+			clpostgres.TestProvide(),
+			clpgmigrate.GooseMigrated(lo.Must(fs.Sub(migrations, "testdata/migrations"))),
+		)
+
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+
+		tnt1, tnt2 = withTenant(ctx, clid.New("tent")), withTenant(ctx, clid.New("tent"))
+	})
+
+	It("should match the ent schema", func(ctx context.Context) {
+		var changes []string
+
+		// plan the auto migration against the migrated database, without applying it
+		Expect(client.Schema.Create(ctx, schema.WithApplyHook(func(schema.Applier) schema.Applier {
+			return schema.ApplyFunc(func(_ context.Context, _ dialect.ExecQuerier, plan *migrate.Plan) error {
+				for _, c := range plan.Changes {
+					changes = append(changes, c.Cmd)
+				}
+
+				return nil
+			})
+		}))).To(Succeed())
+
+		Expect(changes).To(BeEmpty(), "the test migrations differ from the ent schema")
+	})
+
+	It("should set timestamps and version", func() {
+		nt := client.Note.Create().SetBody("foo").SaveX(tnt1)
+		Expect(nt.CreatedAt).ToNot(BeZero())
+		Expect(nt.UpdatedAt).ToNot(BeZero())
+		Expect(nt.Version).To(Equal(0))
+
+		nt = client.Note.UpdateOne(nt).SetBody("bar").SaveX(tnt1)
+		Expect(nt.Version).To(Equal(1))
+		Expect(nt.UpdatedAt).To(BeTemporally(">", nt.CreatedAt))
+	})
+
+	It("should scope to the tenant", func(ctx context.Context) {
+		nt := client.Note.Create().SetBody("foo").SaveX(tnt1)
+		Expect(nt.TenantID.String()).To(HavePrefix("tent-"))
+
+		Expect(client.Note.Query().CountX(tnt1)).To(Equal(1))
+		Expect(client.Note.Query().CountX(tnt2)).To(Equal(0))
+		Expect(client.Note.UpdateOneID(nt.ID).SetBody("bar").Exec(tnt2)).To(MatchError(MatchRegexp(`not found`)))
+
+		_, err := client.Note.Query().All(ctx)
+		Expect(err).To(MatchError(privacy.Deny))
+
+		By("bypassing the policy")
+		Expect(client.Note.Query().CountX(privacy.DecisionContext(ctx, privacy.Allow))).To(Equal(1))
+	})
+
+	It("should soft delete", func() {
+		nt := client.Note.Create().SetBody("foo").SaveX(tnt1)
+		client.Note.DeleteOne(nt).ExecX(tnt1)
+
+		Expect(client.Note.Query().CountX(tnt1)).To(Equal(0))
+
+		deleted := client.Note.Query().Where(note.ID(nt.ID)).OnlyX(clenthook.SkipSoftDelete(tnt1))
+		Expect(deleted.DeletedAt).ToNot(BeNil())
+
+		By("deleting permanently")
+		client.Note.DeleteOne(nt).ExecX(clenthook.SkipSoftDelete(tnt1))
+		Expect(client.Note.Query().CountX(clenthook.SkipSoftDelete(tnt1))).To(Equal(0))
+	})
+
+	It("should lock optimistically", func() {
+		nt := client.Note.Create().SetBody("foo").SaveX(tnt1)
+
+		nt = client.Note.UpdateOne(nt).SetBody("bar").SetVersion(nt.Version).SaveX(tnt1)
+		Expect(nt.Version).To(Equal(1))
+
+		_, err := client.Note.UpdateOne(nt).SetBody("dar").SetVersion(0).Save(tnt1)
+		Expect(err).To(MatchError(clenthook.ErrVersionMismatch))
+
+		By("updating in bulk")
+		_, err = client.Note.Update().Where(note.ID(nt.ID)).SetBody("dar").SetVersion(0).Save(tnt1)
+		Expect(err).To(MatchError(clenthook.ErrVersionMismatch))
+
+		n := client.Note.Update().Where(note.ID(nt.ID)).SetBody("dar").SetVersion(1).SaveX(tnt1)
+		Expect(n).To(Equal(1))
+	})
+})
+
+func withTenant(ctx context.Context, tid clid.ID) context.Context {
+	tok := openid.New()
+	Expect(tok.Set("sub", "user1")).To(Succeed())
+	Expect(tok.Set("tid", tid.String())).To(Succeed())
+
+	return clconnect.WithIdentity(ctx, tok)
+}
@@ -0,0 +1,16 @@
+-- +goose Up
+-- the notes of the test schema, with the columns of the time, soft-delete, tenant and version mixins. It must match
+-- the Ent schema, the "should match the ent schema" spec fails when they differ.
+CREATE TABLE "notes" (
+    "id" character varying(31) NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" timestamptz NULL,
+    "tenant_id" character varying(31) NOT NULL,
+    "version" bigint NOT NULL DEFAULT 0,
+    "body" character varying NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- +goose Down
+DROP TABLE "notes";
@@ -0,0 +1,26 @@
+-- +goose Up
+-- the native clid type, as created by clpgxmigrate.NewCLIDTypeStep
+CREATE TYPE "clid" AS ("prefix" char(4), "ulid" uuid);
+
+-- the teams and members of the test schema, identified by native clids. They must match the Ent schema, the
+-- "should match the ent schema" spec fails when they differ.
+CREATE TABLE "teams" (
+    "id" clid NOT NULL,
+    "name" character varying NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "id_clid_prefix" CHECK (("id").prefix = 'team')
+);
+
+CREATE TABLE "members" (
+    "id" clid NOT NULL,
+    "name" character varying NOT NULL,
+    "team_members" clid NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "members_teams_members" FOREIGN KEY ("team_members") REFERENCES "teams" ("id") ON UPDATE NO ACTION ON DELETE SET NULL,
+    CONSTRAINT "id_clid_prefix" CHECK (("id").prefix = 'mmbr')
+);
+
+-- +goose Down
+DROP TABLE "members";
+DROP TABLE "teams";
+DROP TYPE "clid";
@@ -0,0 +1,283 @@
+package clhealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/crewlinker/clgo/clconfig"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Config configures the health checks.
+type Config struct {
+	// CheckTimeout is how long a check may take, unless the checker configures its own timeout
+	CheckTimeout time.Duration `env:"CHECK_TIMEOUT" envDefault:"2s"`
+	// CacheTTL is how long the result of a check is re-used, so frequent probes don't overload the dependencies
+	CacheTTL time.Duration `env:"CACHE_TTL" envDefault:"1s"`
+	// LivePath is the path where the liveness is served
+	LivePath string `env:"LIVE_PATH" envDefault:"/livez"`
+	// ReadyPath is the path where the readiness is served
+	ReadyPath string `env:"READY_PATH" envDefault:"/readyz"`
+}
+
+// Checker checks the health of a component, such as a database connection.
+type Checker struct {
+	// Name of the check, it must be unique.
+	Name string
+	// Check returns an error if the component is not healthy.
+	Check func(ctx context.Context) error
+	// Liveness also includes the check in the liveness, it should only fail if the process needs a restart.
+	Liveness bool
+	// Timeout of the check, the configured timeout is used if it is zero.
+	Timeout time.Duration
+}
+
+// State of the process, as reported in the health.
+type State string
+
+const (
+	// StateStarting means that not all components have started yet.
+	StateStarting State = "starting"
+	// StateStarted means that all components have started.
+	StateStarted State = "started"
+	// StateStopping means that the components are being stopped.
+	StateStopping State = "stopping"
+)
+
+// Status of a check, or of all checks together.
+type Status string
+
+const (
+	// StatusOK is reported when the check passed.
+	StatusOK Status = "ok"
+	// StatusFail is reported when the check failed.
+	StatusFail Status = "fail"
+)
+
+// Result of a check.
+type Result struct {
+	Status    Status    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Duration  string    `json:"duration"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report is the health of the process, as served in JSON.
+type Report struct {
+	Status Status            `json:"status"`
+	State  State             `json:"state"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Health runs the checks that are registered by the components.
+type Health struct {
+	cfg      Config
+	logs     *zap.Logger
+	checkers []Checker
+	state    atomic.Value
+	cached   map[string]*cachedResult
+}
+
+// cachedResult holds the latest result of a check, the lock makes concurrent probes share a run of the check.
+type cachedResult struct {
+	sync.Mutex
+	res Result
+}
+
+// New inits the health.
+func New(cfg Config, logs *zap.Logger, checkers []Checker) (*Health, error) {
+	h := &Health{cfg: cfg, logs: logs, checkers: checkers, cached: make(map[string]*cachedResult, len(checkers))}
+	h.state.Store(StateStarting)
+
+	for _, c := range checkers {
+		if _, exists := h.cached[c.Name]; exists {
+			return nil, fmt.Errorf("health check with name '%s' is registered more than once", c.Name) //nolint:goerr113
+		}
+
+		h.cached[c.Name] = &cachedResult{}
+	}
+
+	return h, nil
+}
+
+// Start marks the process as started, it is called after the components (that the checks depend on) have started.
+func (h *Health) Start(context.Context) error {
+	h.state.Store(StateStarted)
+
+	return nil
+}
+
+// Stop marks the process as stopping, this fails the readiness.
+func (h *Health) Stop(context.Context) error {
+	h.state.Store(StateStopping)
+
+	return nil
+}
+
+// State returns the state of the process.
+func (h *Health) State() State { return h.state.Load().(State) } //nolint:forcetypeassert
+
+// Live reports the liveness: only the liveness checks are run, and the process is live while starting.
+func (h *Health) Live(ctx context.Context) Report {
+	rep := h.run(ctx, true)
+	if h.State() == StateStopping {
+		rep.Status = StatusOK // don't restart a process that is shutting down
+	}
+
+	return rep
+}
+
+// Ready reports the readiness: all checks are run, and the process is only ready once it has started.
+func (h *Health) Ready(ctx context.Context) Report {
+	rep := h.run(ctx, false)
+	if h.State() != StateStarted {
+		rep.Status = StatusFail
+	}
+
+	return rep
+}
+
+// run the checks concurrently.
+func (h *Health) run(ctx context.Context, liveOnly bool) Report {
+	rep := Report{Status: StatusOK, State: h.State(), Checks: map[string]Result{}}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for _, c := range h.checkers {
+		if liveOnly && !c.Liveness {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			res := h.check(ctx, c)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			rep.Checks[c.Name] = res
+			if res.Status != StatusOK {
+				rep.Status = StatusFail
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return rep
+}
+
+// check runs a single check, or returns its cached result.
+func (h *Health) check(ctx context.Context, c Checker) Result {
+	cr := h.cached[c.Name]
+	cr.Lock()
+	defer cr.Unlock()
+
+	if !cr.res.CheckedAt.IsZero() && time.Since(cr.res.CheckedAt) < h.cfg.CacheTTL {
+		return cr.res
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = h.cfg.CheckTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := c.Check(ctx)
+	res := Result{Status: StatusOK, Duration: time.Since(started).String(), CheckedAt: started}
+
+	if err != nil {
+		res.Status, res.Error = StatusFail, err.Error()
+
+		h.logs.Warn("health check failed", zap.String("check", c.Name), zap.Error(err))
+	}
+
+	// the result of a check that was cancelled by the prober is not cached, it says nothing about the component
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		cr.res = res
+	}
+
+	return res
+}
+
+// LiveHandler serves the liveness as JSON, with a 503 status when it fails.
+func (h *Health) LiveHandler() http.Handler { return serveReport(h.Live) }
+
+// ReadyHandler serves the readiness as JSON, with a 503 status when it fails.
+func (h *Health) ReadyHandler() http.Handler { return serveReport(h.Ready) }
+
+// Handler serves the liveness and readiness on the configured paths, and all other requests with next.
+func (h *Health) Handler(next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(h.cfg.LivePath, h.LiveHandler())
+	mux.Handle(h.cfg.ReadyPath, h.ReadyHandler())
+	mux.Handle("/", next)
+
+	return mux
+}
+
+// serveReport serves the report as JSON.
+func serveReport(report func(context.Context) Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rep := report(r.Context())
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+
+		if rep.Status != StatusOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_ = json.NewEncoder(w).Encode(rep)
+	})
+}
+
+// moduleName standardizes the module name.
+const moduleName = "clhealth"
+
+// checkersGroup is the fx value group of the checkers.
+const checkersGroup = moduleName + ".checkers"
+
+// Provide the health dependencies.
+func Provide() fx.Option {
+	return fx.Module(moduleName,
+		// provide the environment configuration
+		clconfig.Provide[Config](strings.ToUpper(moduleName)+"_"),
+		// the incoming logger will be named after the module
+		fx.Decorate(func(l *zap.Logger) *zap.Logger { return l.Named(moduleName) }),
+		// provide the health, it is started after the components that the checks depend on
+		fx.Provide(fx.Annotate(New,
+			fx.ParamTags(``, ``, `group:"`+checkersGroup+`"`),
+			fx.OnStart(func(ctx context.Context, h *Health) error { return h.Start(ctx) }),
+			fx.OnStop(func(ctx context.Context, h *Health) error { return h.Stop(ctx) }),
+		)),
+	)
+}
+
+// ProvideChecker provides a checker for the health, the constructor must return a Checker. The param tags are
+// applied to the constructor's parameters, e.g. to check a named dependency.
+func ProvideChecker(constructor any, paramTags ...string) fx.Option {
+	anns := []fx.Annotation{fx.ResultTags(`group:"` + checkersGroup + `"`)}
+	if len(paramTags) > 0 {
+		anns = append(anns, fx.ParamTags(paramTags...))
+	}
+
+	return fx.Provide(fx.Annotate(constructor, anns...))
+}
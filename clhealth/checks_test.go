@@ -0,0 +1,142 @@
+package clhealth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/crewlinker/clgo/clhealth"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("health checks", func() {
+	var hlth *clhealth.Health
+	var calls atomic.Int64
+	var failing atomic.Bool
+
+	BeforeEach(func(ctx context.Context) {
+		calls.Store(0)
+		failing.Store(false)
+
+		app := fx.New(
+			clhealth.Provide(),
+			fx.Decorate(func(c clhealth.Config) clhealth.Config {
+				c.CheckTimeout = time.Millisecond * 50
+
+				return c
+			}),
+			clhealth.ProvideChecker(func() clhealth.Checker {
+				return clhealth.Checker{Name: "db", Check: func(context.Context) error {
+					calls.Add(1)
+					if failing.Load() {
+						return errors.New("db is down")
+					}
+
+					return nil
+				}}
+			}),
+			clhealth.ProvideChecker(func() clhealth.Checker {
+				return clhealth.Checker{Name: "loop", Liveness: true, Check: func(context.Context) error { return nil }}
+			}),
+			fx.Populate(&hlth),
+			clzap.TestProvide())
+
+		Expect(hlth.State()).To(Equal(clhealth.StateStarting))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	serve := func(h http.Handler) (int, clhealth.Report) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		Expect(rec.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		var rep clhealth.Report
+		Expect(json.NewDecoder(rec.Body).Decode(&rep)).To(Succeed())
+
+		return rec.Code, rep
+	}
+
+	It("should be ready and live", func() {
+		code, rep := serve(hlth.ReadyHandler())
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(rep.Status).To(Equal(clhealth.StatusOK))
+		Expect(rep.State).To(Equal(clhealth.StateStarted))
+		Expect(rep.Checks).To(HaveLen(2))
+		Expect(rep.Checks["db"].Status).To(Equal(clhealth.StatusOK))
+
+		code, rep = serve(hlth.LiveHandler())
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(rep.Checks).To(HaveLen(1))
+		Expect(rep.Checks).To(HaveKey("loop"))
+	})
+
+	It("should fail readiness with detail, but stay live", func() {
+		failing.Store(true)
+
+		code, rep := serve(hlth.ReadyHandler())
+		Expect(code).To(Equal(http.StatusServiceUnavailable))
+		Expect(rep.Status).To(Equal(clhealth.StatusFail))
+		Expect(rep.Checks["db"].Error).To(Equal("db is down"))
+
+		code, _ = serve(hlth.LiveHandler())
+		Expect(code).To(Equal(http.StatusOK))
+	})
+
+	It("should cache results", func(ctx context.Context) {
+		hlth.Ready(ctx)
+		hlth.Ready(ctx)
+		Expect(calls.Load()).To(Equal(int64(1)))
+	})
+
+	It("should fail readiness while stopping", func(ctx context.Context) {
+		Expect(hlth.Stop(ctx)).To(Succeed())
+
+		code, rep := serve(hlth.ReadyHandler())
+		Expect(code).To(Equal(http.StatusServiceUnavailable))
+		Expect(rep.State).To(Equal(clhealth.StateStopping))
+
+		code, _ = serve(hlth.LiveHandler())
+		Expect(code).To(Equal(http.StatusOK))
+	})
+})
+
+var _ = Describe("check timeouts", func() {
+	It("should time out slow checks", func(ctx context.Context) {
+		hlth, err := clhealth.New(clhealth.Config{CheckTimeout: time.Second}, zap.NewNop(), []clhealth.Checker{{
+			Name:    "slow",
+			Timeout: time.Millisecond * 10,
+			Check: func(ctx context.Context) error {
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hlth.Start(ctx)).To(Succeed())
+
+		rep := hlth.Ready(ctx)
+		Expect(rep.Status).To(Equal(clhealth.StatusFail))
+		Expect(rep.Checks["slow"].Error).To(Equal(context.DeadlineExceeded.Error()))
+	})
+
+	It("should not be ready while starting", func(ctx context.Context) {
+		hlth, err := clhealth.New(clhealth.Config{}, zap.NewNop(), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hlth.Ready(ctx).Status).To(Equal(clhealth.StatusFail))
+		Expect(hlth.Live(ctx).Status).To(Equal(clhealth.StatusOK))
+	})
+
+	It("should not allow duplicate names", func() {
+		_, err := clhealth.New(clhealth.Config{}, zap.NewNop(), []clhealth.Checker{{Name: "a"}, {Name: "a"}})
+		Expect(err).To(MatchError(ContainSubstring("more than once")))
+	})
+})
@@ -16,11 +16,13 @@ import (
 var (
 	healthCheckTimeout = time.Second * 2
 	invalidExitCode    = 2
+	maxDetailBytes     = int64(4096)
 )
 
 // CheckHealthAndExit will run the fx.App unless the osArgs indicate that the
 // user wants to run a healthcheck. This is useful since our containers might
-// not contain curl or wget.
+// not contain curl or wget. The URL can point to the readiness or liveness that
+// is served by Health, a failing check's JSON detail is then written to errWriter.
 func CheckHealthAndExit(ctx context.Context, errWriter io.Writer, osArgs []string, exitFn func(int)) {
 	if len(osArgs) != 4 || osArgs[1] != "healthcheck" {
 		return
@@ -42,11 +44,16 @@ func CheckHealthAndExit(ctx context.Context, errWriter io.Writer, osArgs []strin
 		return
 	}
 
-	resp.Body.Close()
+	defer resp.Body.Close()
 
 	// if we have an unexpected status code, exit with code 1
 	if strconv.Itoa(resp.StatusCode) != osArgs[3] {
 		fmt.Fprintf(errWriter, "%v", resp.Status)
+
+		if detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxDetailBytes)); len(detail) > 0 {
+			fmt.Fprintf(errWriter, ": %s", detail)
+		}
+
 		exitFn(1)
 
 		return
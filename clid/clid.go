@@ -3,6 +3,7 @@ package clid
 
 import (
 	"database/sql/driver"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -29,11 +30,11 @@ type ID struct {
 	d ulid.ULID
 }
 
-// New with default tme and entropy sources and panic when it fails.
+// New generates an id with the DefaultGenerator and panics when it fails. Use Generate to handle the error.
 func New(prefix string) (id ID) {
-	id, err := NewFromParts(prefix, ulid.Now(), ulid.DefaultEntropy())
+	id, err := Generate(prefix)
 	if err != nil {
-		panic("clid: " + err.Error())
+		panic(err.Error())
 	}
 
 	return
@@ -55,6 +56,28 @@ func NewFromParts(prefix string, ms uint64, entr io.Reader) (id ID, err error) {
 	return id, nil
 }
 
+// FromULID creates an id from a prefix and an existing ulid.
+func FromULID(prefix string, d ulid.ULID) ID {
+	if len(prefix) != PrefixSize {
+		panic(fmt.Sprintf("clid: prefix size must be: %d", PrefixSize))
+	}
+
+	return ID{p: prefix, d: d}
+}
+
+// Prefix returns the prefix of the id, it is empty for the zero value.
+func (id ID) Prefix() string { return id.p }
+
+// ULID returns the ulid part of the identifier.
+func (id ID) ULID() ulid.ULID { return id.d }
+
+// UUIDString formats the ulid part as an uuid, this is how it is stored natively in Postgres.
+func (id ID) UUIDString() string {
+	h := hex.EncodeToString(id.d[:])
+
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
 // String implements the fmt.Stringer interface.
 func (id ID) String() string {
 	if id.p == "" {
@@ -88,9 +111,15 @@ func (id *ID) Scan(v any) error {
 	)
 
 	switch v := v.(type) {
+	case []byte:
+		return id.Scan(string(v))
 	case string:
 		*id = ID{}
 
+		if strings.HasPrefix(v, "(") {
+			return id.scanComposite(v)
+		}
+
 		id.p, after, found = strings.Cut(v, Separator)
 		if !found {
 			return ScanError{v: v, m: "missing separator '" + Separator + "'"}
@@ -107,6 +136,41 @@ func (id *ID) Scan(v any) error {
 	}
 }
 
+// scanComposite scans the text representation of the native Postgres composite type: (prefix,uuid).
+func (id *ID) scanComposite(v string) (err error) {
+	prefix, uuid, found := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(v, "("), ")"), ",")
+	if !found {
+		return ScanError{v: v, m: "missing composite separator ','"}
+	}
+
+	id.p = strings.Trim(prefix, `"`)
+	if len(id.p) != PrefixSize {
+		return ScanError{v: v, m: "prefix must be of size " + strconv.Itoa(PrefixSize)}
+	}
+
+	if id.d, err = parseUUID(strings.Trim(uuid, `"`)); err != nil {
+		return fmt.Errorf("clid: %w", err)
+	}
+
+	return nil
+}
+
+// parseUUID parses the uuid text format into the ulid.
+func parseUUID(s string) (d ulid.ULID, err error) {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil {
+		return d, fmt.Errorf("invalid uuid: %w", err)
+	}
+
+	if len(b) != len(d) {
+		return d, fmt.Errorf("invalid uuid size: %d", len(b)) //nolint:goerr113
+	}
+
+	copy(d[:], b)
+
+	return d, nil
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (id ID) MarshalJSON() (data []byte, err error) {
 	return json.Marshal(id.String()) //nolint:wrapcheck
@@ -122,6 +186,44 @@ func (id *ID) UnmarshalJSON(data []byte) error {
 	return id.Scan(s)
 }
 
+// MarshalText implements the encoding.TextMarshaler interface.
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (id *ID) UnmarshalText(data []byte) error {
+	return id.Scan(string(data))
+}
+
+// BinarySize is the size of the binary encoding: the prefix followed by the 16 bytes of the ulid.
+const BinarySize = PrefixSize + len(ulid.ULID{})
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The prefix of the zero value is encoded as
+// zero bytes so it survives the round trip.
+func (id ID) MarshalBinary() ([]byte, error) {
+	data := make([]byte, PrefixSize, BinarySize)
+	copy(data, id.p)
+
+	return append(data, id.d[:]...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (id *ID) UnmarshalBinary(data []byte) error {
+	if len(data) != BinarySize {
+		return fmt.Errorf("%w", ScanError{v: data, m: "binary must be of size " + strconv.Itoa(BinarySize)})
+	}
+
+	*id = ID{}
+	if prefix := string(data[:PrefixSize]); prefix != string(make([]byte, PrefixSize)) {
+		id.p = prefix
+	}
+
+	copy(id.d[:], data[PrefixSize:])
+
+	return nil
+}
+
 // MarshalGQL marshals the identifier.
 func (id ID) MarshalGQL(w io.Writer) {
 	_, _ = w.Write([]byte(strconv.Quote(id.String())))
@@ -0,0 +1,135 @@
+package clid
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// ErrInvalidPrefix is returned when an id is generated with a prefix of the wrong size.
+var ErrInvalidPrefix = fmt.Errorf("clid: prefix size must be: %d", PrefixSize)
+
+// MaxBatchSize is the maximum number of ids that are generated in one batch, the lock is held for the whole batch.
+const MaxBatchSize = 1 << 16
+
+// ErrInvalidBatchSize is returned when a batch of ids is generated with a negative size, or a size that is too large.
+var ErrInvalidBatchSize = fmt.Errorf("clid: batch size must be between 0 and %d", MaxBatchSize)
+
+// GeneratorOption configures a Generator.
+type GeneratorOption func(*Generator)
+
+// WithClock configures the clock that determines the timestamp of generated ids.
+func WithClock(now func() time.Time) GeneratorOption {
+	return func(g *Generator) {
+		g.now = now
+	}
+}
+
+// WithEntropy configures the source of randomness for generated ids. It doesn't need to be safe for concurrent
+// use, the generator serializes all reads.
+func WithEntropy(r io.Reader) GeneratorOption {
+	return func(g *Generator) {
+		g.entr = ulid.Monotonic(r, 0)
+	}
+}
+
+// Generator generates ids that are strictly increasing for the process, also when many are generated within the
+// same millisecond or when the clock moves backwards. It is safe for concurrent use.
+type Generator struct {
+	mu   sync.Mutex
+	now  func() time.Time
+	entr *ulid.MonotonicEntropy
+	ms   uint64
+}
+
+// NewGenerator inits a generator, by default it uses the system clock and crypto/rand for entropy.
+func NewGenerator(opts ...GeneratorOption) *Generator {
+	gen := &Generator{now: time.Now, entr: ulid.Monotonic(rand.Reader, 0)}
+	for _, o := range opts {
+		o(gen)
+	}
+
+	return gen
+}
+
+// New generates an id with the prefix.
+func (g *Generator) New(prefix string) (ID, error) {
+	if len(prefix) != PrefixSize {
+		return ID{}, ErrInvalidPrefix
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.next(prefix)
+}
+
+// NewBatch generates n ids with the prefix, the ids are strictly increasing. At most MaxBatchSize ids are generated
+// at once.
+func (g *Generator) NewBatch(prefix string, n int) ([]ID, error) {
+	if len(prefix) != PrefixSize {
+		return nil, ErrInvalidPrefix
+	}
+
+	if n < 0 || n > MaxBatchSize {
+		return nil, ErrInvalidBatchSize
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ids := make([]ID, n)
+	for i := range ids {
+		id, err := g.next(prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// next generates the next id, must be called while holding the lock.
+func (g *Generator) next(prefix string) (ID, error) {
+	ms := ulid.Timestamp(g.now())
+	if ms < g.ms {
+		ms = g.ms // clock moved backwards, keep using the last timestamp to stay ordered.
+	}
+
+	for {
+		d, err := ulid.New(ms, g.entr)
+
+		switch {
+		case errors.Is(err, ulid.ErrMonotonicOverflow):
+			ms++ // exhausted the entropy within the ms, borrow from the next one.
+
+			continue
+		case err != nil:
+			return ID{}, fmt.Errorf("clid: failed to generate ulid: %w", err)
+		}
+
+		g.ms = ms
+
+		return ID{p: prefix, d: d}, nil
+	}
+}
+
+// DefaultGenerator is used by the package-level functions that generate ids.
+var DefaultGenerator = NewGenerator()
+
+// Generate an id with the default generator, it returns an error instead of panicking.
+func Generate(prefix string) (ID, error) {
+	return DefaultGenerator.New(prefix)
+}
+
+// GenerateBatch generates n strictly increasing ids with the default generator.
+func GenerateBatch(prefix string, n int) ([]ID, error) {
+	return DefaultGenerator.NewBatch(prefix, n)
+}
@@ -0,0 +1,170 @@
+package clid_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crewlinker/clgo/clid"
+	"github.com/oklog/ulid/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// maxEntropy reads 0xff bytes for the first ulid such that its entropy overflows on the first increment.
+type maxEntropy struct{ n int }
+
+func (e *maxEntropy) Read(p []byte) (int, error) {
+	for i := range p {
+		if p[i] = 0x01; e.n < 10 {
+			p[i] = 0xff
+		}
+
+		e.n++
+	}
+
+	return len(p), nil
+}
+
+// expectStrictlyIncreasing asserts the ids are strictly ordered by their ulid.
+func expectStrictlyIncreasing(g Gomega, ids []clid.ID) {
+	for i := 1; i < len(ids); i++ {
+		g.Expect(ids[i-1].ULID().Compare(ids[i].ULID())).To(Equal(-1), "at %d: %s >= %s", i, ids[i-1], ids[i])
+	}
+}
+
+var _ = Describe("generator", func() {
+	var now time.Time
+
+	BeforeEach(func() {
+		now = time.Unix(1701767130, 0)
+	})
+
+	It("should generate with the clock", func() {
+		gen := clid.NewGenerator(clid.WithClock(func() time.Time { return now }))
+
+		id, err := gen.New("user")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id.Prefix()).To(Equal("user"))
+		Expect(id.ULID().Time()).To(Equal(ulid.Timestamp(now)))
+	})
+
+	It("should error on invalid prefix", func() {
+		_, err := clid.NewGenerator().New("a")
+		Expect(err).To(MatchError(clid.ErrInvalidPrefix))
+		_, err = clid.GenerateBatch("a", 1)
+		Expect(err).To(MatchError(clid.ErrInvalidPrefix))
+		Expect(func() { clid.New("a") }).To(PanicWith(MatchRegexp(`prefix size must be`)))
+	})
+
+	It("should error on invalid batch size", func() {
+		_, err := clid.GenerateBatch("user", -1)
+		Expect(err).To(MatchError(clid.ErrInvalidBatchSize))
+		_, err = clid.GenerateBatch("user", clid.MaxBatchSize+1)
+		Expect(err).To(MatchError(clid.ErrInvalidBatchSize))
+
+		ids, err := clid.GenerateBatch("user", 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ids).To(BeEmpty())
+	})
+
+	It("should be strictly increasing within the same ms", func() {
+		gen := clid.NewGenerator(clid.WithClock(func() time.Time { return now }))
+
+		ids, err := gen.NewBatch("user", 1000)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ids).To(HaveLen(1000))
+		expectStrictlyIncreasing(Default, ids)
+	})
+
+	It("should stay ordered when the clock moves backwards", func() {
+		gen := clid.NewGenerator(clid.WithClock(func() time.Time { return now }))
+
+		id1, err := gen.New("user")
+		Expect(err).ToNot(HaveOccurred())
+
+		now = now.Add(-time.Hour)
+
+		id2, err := gen.New("user")
+		Expect(err).ToNot(HaveOccurred())
+		expectStrictlyIncreasing(Default, []clid.ID{id1, id2})
+		Expect(id2.ULID().Time()).To(Equal(id1.ULID().Time()))
+	})
+
+	It("should borrow from the next ms when entropy overflows", func() {
+		gen := clid.NewGenerator(
+			clid.WithClock(func() time.Time { return now }),
+			clid.WithEntropy(&maxEntropy{}))
+
+		ids, err := gen.NewBatch("user", 2)
+		Expect(err).ToNot(HaveOccurred())
+		expectStrictlyIncreasing(Default, ids)
+		Expect(ids[1].ULID().Time()).To(Equal(ulid.Timestamp(now) + 1))
+	})
+
+	It("should be unique and ordered under parallel load", func() {
+		const workers, perWorker = 32, 2000
+
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			seen = map[clid.ID]struct{}{}
+		)
+
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				ids := make([]clid.ID, 0, perWorker)
+				for j := 0; j < perWorker; j++ {
+					id, err := clid.Generate("user")
+					Expect(err).ToNot(HaveOccurred())
+
+					ids = append(ids, id)
+				}
+
+				expectStrictlyIncreasing(Default, ids)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				for _, id := range ids {
+					seen[id] = struct{}{}
+				}
+			}()
+		}
+
+		wg.Wait()
+		Expect(seen).To(HaveLen(workers * perWorker))
+	})
+})
+
+func FuzzGeneratorBatch(f *testing.F) {
+	f.Add(int64(1701767130000), int64(1), uint16(100))
+	f.Add(int64(0), int64(42), uint16(1))
+	f.Add(int64(253402300799000), int64(7), uint16(1000))
+
+	f.Fuzz(func(t *testing.T, ms, seed int64, n uint16) {
+		g := NewWithT(t)
+		if ms < 0 || uint64(ms) > ulid.MaxTime()-uint64(n) {
+			t.Skip()
+		}
+
+		gen := clid.NewGenerator(
+			clid.WithClock(func() time.Time { return time.UnixMilli(ms) }),
+			clid.WithEntropy(rand.New(rand.NewSource(seed)))) //nolint:gosec
+
+		ids, err := gen.NewBatch("fuzz", int(n))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ids).To(HaveLen(int(n)))
+		expectStrictlyIncreasing(g, ids)
+
+		for _, id := range ids {
+			g.Expect(id.ULID().Time()).To(BeNumerically(">=", uint64(ms)))
+		}
+	})
+}
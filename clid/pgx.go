@@ -0,0 +1,244 @@
+package clid
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PostgresTypeName is the name of the composite type that stores the id natively: (prefix char(4), ulid uuid).
+const PostgresTypeName = "clid"
+
+// composite field oids as defined by Postgres, used in the binary format.
+const (
+	bpcharOID = 1042
+	uuidOID   = 2950
+)
+
+// Native wraps the ID such that database/sql encodes it as the native composite type instead of text. It is meant
+// to be used as the Go type for columns of the native type.
+type Native struct{ ID }
+
+// Value implements the driver Valuer interface by encoding the text representation of the composite.
+func (id Native) Value() (driver.Value, error) {
+	buf, err := encodeText(id.ID, nil)
+
+	return string(buf), err
+}
+
+// RegisterPgxType looks up the oid of the native composite type and registers the Codec with the connection. This
+// is usually called from the AfterConnect hook of a pgxpool.Config.
+func RegisterPgxType(ctx context.Context, conn *pgx.Conn) error {
+	var oid uint32
+	if err := conn.QueryRow(ctx, `SELECT $1::regtype::oid`, PostgresTypeName).Scan(&oid); err != nil {
+		return fmt.Errorf("clid: failed to lookup oid of type '%s': %w", PostgresTypeName, err)
+	}
+
+	conn.TypeMap().RegisterType(&pgtype.Type{Name: PostgresTypeName, OID: oid, Codec: Codec{}})
+
+	return nil
+}
+
+// Codec implements the pgtype.Codec for scanning and encoding the id in the native composite type without going
+// through database/sql.
+type Codec struct{}
+
+// FormatSupported returns true if the format is supported.
+func (Codec) FormatSupported(format int16) bool {
+	return format == pgtype.TextFormatCode || format == pgtype.BinaryFormatCode
+}
+
+// PreferredFormat returns the preferred format.
+func (Codec) PreferredFormat() int16 { return pgtype.BinaryFormatCode }
+
+// PlanEncode returns an EncodePlan for encoding the id.
+func (Codec) PlanEncode(_ *pgtype.Map, _ uint32, format int16, value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case ID, *ID, Native, *Native, string:
+	default:
+		return nil
+	}
+
+	if format == pgtype.BinaryFormatCode {
+		return encodePlan(encodeBinary)
+	}
+
+	return encodePlan(encodeText)
+}
+
+// PlanScan returns a ScanPlan for scanning into an id (or its string representation).
+func (Codec) PlanScan(_ *pgtype.Map, _ uint32, format int16, target any) pgtype.ScanPlan {
+	switch target.(type) {
+	case *ID, *Native, *string:
+	default:
+		return nil
+	}
+
+	return scanPlan(format)
+}
+
+// DecodeDatabaseSQLValue returns the string representation of the id so it can be scanned by database/sql.
+func (Codec) DecodeDatabaseSQLValue(_ *pgtype.Map, _ uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	id, err := decode(format, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return id.String(), nil
+}
+
+// DecodeValue returns src decoded into an ID.
+func (Codec) DecodeValue(_ *pgtype.Map, _ uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	return decode(format, src)
+}
+
+// encodePlan implements the pgtype.EncodePlan for the supported values.
+type encodePlan func(id ID, buf []byte) ([]byte, error)
+
+func (p encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	var id ID
+
+	switch v := value.(type) {
+	case ID:
+		id = v
+	case *ID:
+		if v == nil {
+			return nil, nil
+		}
+
+		id = *v
+	case Native:
+		id = v.ID
+	case *Native:
+		if v == nil {
+			return nil, nil
+		}
+
+		id = v.ID
+	case string:
+		if err := id.Scan(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(id.p) != PrefixSize {
+		return nil, fmt.Errorf("%w", ScanError{v: value, m: "prefix must be of size " + strconv.Itoa(PrefixSize)})
+	}
+
+	return p(id, buf)
+}
+
+// encodeBinary encodes the composite in the binary record format: field count, then for each field the oid,
+// the length and the data.
+func encodeBinary(id ID, buf []byte) ([]byte, error) {
+	buf = binary.BigEndian.AppendUint32(buf, 2) //nolint:gomnd
+	buf = binary.BigEndian.AppendUint32(buf, bpcharOID)
+	buf = binary.BigEndian.AppendUint32(buf, PrefixSize)
+	buf = append(buf, id.p...)
+	buf = binary.BigEndian.AppendUint32(buf, uuidOID)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(id.d)))
+
+	return append(buf, id.d[:]...), nil
+}
+
+// encodeText encodes the composite in its text format.
+func encodeText(id ID, buf []byte) ([]byte, error) {
+	return append(buf, "("+id.p+","+id.UUIDString()+")"...), nil
+}
+
+// scanPlan implements the pgtype.ScanPlan for the format.
+type scanPlan int16
+
+func (p scanPlan) Scan(src []byte, target any) error {
+	if src == nil {
+		return fmt.Errorf("%w", ScanError{v: target, m: "cannot scan NULL"})
+	}
+
+	id, err := decode(int16(p), src)
+	if err != nil {
+		return err
+	}
+
+	switch t := target.(type) {
+	case *ID:
+		*t = id
+	case *Native:
+		t.ID = id
+	case *string:
+		*t = id.String()
+	default:
+		return fmt.Errorf("%w", ScanError{v: target, m: "type not supported"})
+	}
+
+	return nil
+}
+
+// decode the id from either format.
+func decode(format int16, src []byte) (id ID, err error) {
+	if format == pgtype.TextFormatCode {
+		err = id.Scan(string(src))
+
+		return id, err
+	}
+
+	const fieldsSize = 4
+	if len(src) < fieldsSize || binary.BigEndian.Uint32(src) != 2 {
+		return id, fmt.Errorf("%w", ScanError{v: src, m: "invalid binary composite: expected 2 fields"})
+	}
+
+	prefix, src, err := decodeField(src[fieldsSize:], bpcharOID, PrefixSize)
+	if err != nil {
+		return id, err
+	}
+
+	data, src, err := decodeField(src, uuidOID, len(id.d))
+	if err != nil {
+		return id, err
+	}
+
+	if len(src) != 0 {
+		return id, fmt.Errorf("%w", ScanError{v: src, m: "invalid binary composite: trailing data"})
+	}
+
+	id.p = string(prefix)
+	copy(id.d[:], data)
+
+	return id, nil
+}
+
+// decodeField decodes a field of the binary composite: the oid, the length (-1 for NULL) and the data. It returns
+// the data and the remainder of src.
+func decodeField(src []byte, oid uint32, size int) (data, rest []byte, err error) {
+	const headerSize = 8
+	if len(src) < headerSize {
+		return nil, nil, fmt.Errorf("%w", ScanError{v: src, m: "invalid binary composite: truncated field"})
+	}
+
+	if foid := binary.BigEndian.Uint32(src); foid != oid {
+		return nil, nil, fmt.Errorf("%w", ScanError{v: src, m: fmt.Sprintf(
+			"invalid binary composite: field oid %d, expected: %d", foid, oid)})
+	}
+
+	switch n := int32(binary.BigEndian.Uint32(src[4:])); { //nolint:gosec
+	case n < 0:
+		return nil, nil, fmt.Errorf("%w", ScanError{v: src, m: "invalid binary composite: field is NULL"})
+	case int(n) != size || len(src) < headerSize+size:
+		return nil, nil, fmt.Errorf("%w", ScanError{v: src, m: fmt.Sprintf(
+			"invalid binary composite: field size %d, expected: %d", n, size)})
+	}
+
+	return src[headerSize : headerSize+size], src[headerSize+size:], nil
+}
@@ -0,0 +1,121 @@
+package clid_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"math"
+
+	"github.com/crewlinker/clgo/clid"
+	"github.com/jackc/pgx/v5/pgtype"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	_ pgtype.Codec  = clid.Codec{}
+	_ sql.Scanner   = &clid.Native{}
+	_ driver.Valuer = clid.Native{}
+)
+
+var _ = Describe("native storage", func() {
+	var id clid.ID
+
+	BeforeEach(func() {
+		Expect(id.Scan("user-01HGWKKAWGABYZR1S1G9JMY5HZ")).To(Succeed())
+	})
+
+	It("should expose parts", func() {
+		Expect(id.Prefix()).To(Equal("user"))
+		Expect(id.UUIDString()).To(Equal("018c3939-ab90-52fd-fc07-2182654f163f"))
+		Expect(clid.FromULID("user", id.ULID())).To(Equal(id))
+	})
+
+	It("should scan composite text", func() {
+		var act clid.ID
+		Expect(act.Scan([]byte("(user,018c3939-ab90-52fd-fc07-2182654f163f)"))).To(Succeed())
+		Expect(act).To(Equal(id))
+
+		Expect(act.Scan("(user)")).To(MatchError(ContainSubstring(`missing composite separator`)))
+		Expect(act.Scan("(user,xyz)")).To(MatchError(ContainSubstring(`invalid uuid`)))
+		Expect(act.Scan("(usr,018c3939-ab90-52fd-fc07-2182654f163f)")).
+			To(MatchError(ContainSubstring(`prefix must be of size 4`)))
+		Expect(act.Scan("(users,018c3939-ab90-52fd-fc07-2182654f163f)")).
+			To(MatchError(ContainSubstring(`prefix must be of size 4`)))
+	})
+
+	It("should value as composite text", func() {
+		val, err := clid.Native{ID: id}.Value()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(val).To(Equal("(user,018c3939-ab90-52fd-fc07-2182654f163f)"))
+	})
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		It("should encode and scan", func() {
+			m := pgtype.NewMap()
+			m.RegisterType(&pgtype.Type{Name: clid.PostgresTypeName, OID: 99999, Codec: clid.Codec{}})
+
+			buf, err := m.Encode(99999, format, id, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			var act clid.ID
+			Expect(m.Scan(99999, format, buf, &act)).To(Succeed())
+			Expect(act).To(Equal(id))
+
+			var actn clid.Native
+			Expect(m.Scan(99999, format, buf, &actn)).To(Succeed())
+			Expect(actn.ID).To(Equal(id))
+
+			val, err := clid.Codec{}.DecodeDatabaseSQLValue(m, 99999, format, buf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(val).To(Equal("user-01HGWKKAWGABYZR1S1G9JMY5HZ"))
+		})
+	}
+
+	It("should error on invalid binary", func() {
+		var act clid.ID
+		Expect(clid.Codec{}.PlanScan(nil, 0, pgtype.BinaryFormatCode, &act).Scan([]byte{1, 2}, &act)).
+			To(MatchError(ContainSubstring(`invalid binary composite`)))
+	})
+
+	DescribeTable("should error on invalid binary fields", func(corrupt func(buf []byte) []byte, msg string) {
+		buf, err := clid.Codec{}.PlanEncode(nil, 0, pgtype.BinaryFormatCode, id).Encode(id, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		var act clid.ID
+		Expect(clid.Codec{}.PlanScan(nil, 0, pgtype.BinaryFormatCode, &act).Scan(corrupt(buf), &act)).
+			To(MatchError(ContainSubstring(msg)))
+	},
+		Entry("prefix oid", func(buf []byte) []byte {
+			binary.BigEndian.PutUint32(buf[4:], 25)
+
+			return buf
+		}, "field oid 25"),
+		Entry("null prefix", func(buf []byte) []byte {
+			binary.BigEndian.PutUint32(buf[8:], math.MaxUint32)
+
+			return buf
+		}, "field is NULL"),
+		Entry("null ulid", func(buf []byte) []byte {
+			binary.BigEndian.PutUint32(buf[20:], math.MaxUint32)
+
+			return buf[:24]
+		}, "field is NULL"),
+		Entry("ulid size", func(buf []byte) []byte {
+			binary.BigEndian.PutUint32(buf[20:], 8)
+
+			return buf[:32]
+		}, "field size 8"),
+		Entry("trailing data", func(buf []byte) []byte { return append(buf, 1) }, "trailing data"),
+	)
+
+	It("should error on unsupported targets", func() {
+		m := pgtype.NewMap()
+		buf, err := clid.Codec{}.PlanEncode(m, 0, pgtype.TextFormatCode, id).Encode(id, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		var act int
+		Expect(clid.Codec{}.PlanScan(m, 0, pgtype.TextFormatCode, &clid.ID{}).Scan(buf, &act)).
+			To(MatchError(ContainSubstring(`type not supported`)))
+	})
+})
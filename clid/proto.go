@@ -0,0 +1,34 @@
+package clid
+
+import (
+	"errors"
+
+	clidv1 "github.com/crewlinker/clgo/clid/v1"
+)
+
+// Proto returns the protobuf message for the id.
+func (id ID) Proto() *clidv1.CLID {
+	return &clidv1.CLID{Value: id.String()}
+}
+
+// FromProto parses the id from its protobuf message.
+func FromProto(msg *clidv1.CLID) (id ID, err error) {
+	if msg == nil {
+		return id, errors.New("clid: nil protobuf message") //nolint:goerr113
+	}
+
+	err = id.Scan(msg.GetValue())
+
+	return id, err
+}
+
+// TypedFromProto parses a typed id from its protobuf message, it fails if the prefix is not as expected.
+func TypedFromProto[P Prefix](msg *clidv1.CLID) (id Typed[P], err error) {
+	if msg == nil {
+		return id, errors.New("clid: nil protobuf message") //nolint:goerr113
+	}
+
+	err = id.Scan(msg.GetValue())
+
+	return id, err
+}
@@ -0,0 +1,43 @@
+package clid_test
+
+import (
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/crewlinker/clgo/clid"
+	clidv1 "github.com/crewlinker/clgo/clid/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("proto", func() {
+	It("should convert to and from proto", func() {
+		id := clid.New("user")
+		act, err := clid.FromProto(id.Proto())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(act).To(Equal(id))
+
+		tact, err := clid.TypedFromProto[userPrefix](id.Proto())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tact.Untyped()).To(Equal(id))
+
+		_, err = clid.TypedFromProto[orgaPrefix](id.Proto())
+		Expect(err).To(MatchError(clid.PrefixError{Expected: "orga", Actual: "user"}))
+
+		_, err = clid.FromProto(nil)
+		Expect(err).To(MatchError(`clid: nil protobuf message`))
+	})
+
+	It("should validate the format", func() {
+		val, err := protovalidate.New()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(val.Validate(clid.New("user").Proto())).To(Succeed())
+		Expect(val.Validate(&clidv1.CLID{Value: "user-01hgwkkawgabyzr1s1g9jmy5hz"})).To(Succeed())
+
+		for _, v := range []string{
+			"", "user", "use-01HGWKKAWGABYZR1S1G9JMY5HZ", "user_01HGWKKAWGABYZR1S1G9JMY5HZ",
+			"user-81HGWKKAWGABYZR1S1G9JMY5HZ", "user-01HGWKKAWGABYZR1S1G9JMY5HU", "user-01HGWKKAWGABYZR1S1G9JMY5HZZ",
+		} {
+			Expect(val.Validate(&clidv1.CLID{Value: v})).To(MatchError(ContainSubstring(`clid.format`)), v)
+		}
+	})
+})
@@ -0,0 +1,44 @@
+package clid
+
+import (
+	"fmt"
+	"sync"
+)
+
+// registry maps prefixes to the name of the entity they identify.
+var registry = struct {
+	sync.RWMutex
+	entities map[string]string
+}{entities: map[string]string{}}
+
+// Register the name of the entity that is identified by ids with the prefix. It is meant to be called from init
+// functions and panics when the prefix is invalid or already registered.
+func Register(prefix, entity string) {
+	if len(prefix) != PrefixSize {
+		panic(fmt.Sprintf("clid: prefix size must be: %d", PrefixSize))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if existing, ok := registry.entities[prefix]; ok {
+		panic(fmt.Sprintf("clid: prefix '%s' already registered for entity: %s", prefix, existing))
+	}
+
+	registry.entities[prefix] = entity
+}
+
+// Entity returns the name of the entity that is registered for the prefix.
+func Entity(prefix string) (string, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	entity, ok := registry.entities[prefix]
+
+	return entity, ok
+}
+
+// Entity returns the name of the entity that is registered for the id's prefix.
+func (id ID) Entity() (string, bool) {
+	return Entity(id.p)
+}
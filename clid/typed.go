@@ -0,0 +1,146 @@
+package clid
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+
+	clidv1 "github.com/crewlinker/clgo/clid/v1"
+	"github.com/oklog/ulid/v2"
+)
+
+// Prefix is implemented by (zero-sized) types that determine the prefix of a typed id.
+type Prefix interface {
+	Prefix() string
+}
+
+// PrefixError is returned when a typed id is decoded from a value with a different prefix.
+type PrefixError struct {
+	Expected string
+	Actual   string
+}
+
+func (e PrefixError) Error() string {
+	return fmt.Sprintf("clid: unexpected prefix '%s', expected: '%s'", e.Actual, e.Expected)
+}
+
+// Typed is an id that has its prefix determined by the type parameter. Such that an id of one entity cannot be used
+// where the id of another entity is expected. Decoding fails when the prefix is not as expected. The zero value has
+// no prefix, it is encoded as the zero ID and can be decoded again.
+type Typed[P Prefix] struct{ id ID }
+
+// NewTyped creates a new typed id with default time and entropy sources and panics when it fails.
+func NewTyped[P Prefix]() Typed[P] {
+	var p P
+
+	return Typed[P]{New(p.Prefix())}
+}
+
+// TypedFrom types the id, it fails if the prefix is not as expected. The zero ID results in the zero value.
+func TypedFrom[P Prefix](id ID) (tid Typed[P], err error) {
+	var p P
+	if id == (ID{}) {
+		return tid, nil
+	}
+
+	if id.p != p.Prefix() {
+		return tid, PrefixError{Expected: p.Prefix(), Actual: id.p}
+	}
+
+	return Typed[P]{id}, nil
+}
+
+// ParseTyped parses a typed id from its string representation.
+func ParseTyped[P Prefix](s string) (id Typed[P], err error) {
+	err = id.Scan(s)
+
+	return id, err
+}
+
+// Untyped returns the id without its prefix type.
+func (id Typed[P]) Untyped() ID { return id.id }
+
+// Prefix returns the prefix of the id, it is empty for the zero value.
+func (id Typed[P]) Prefix() string { return id.id.Prefix() }
+
+// ULID returns the ulid part of the identifier.
+func (id Typed[P]) ULID() ulid.ULID { return id.id.ULID() }
+
+// UUIDString formats the ulid part as an uuid, this is how it is stored natively in Postgres.
+func (id Typed[P]) UUIDString() string { return id.id.UUIDString() }
+
+// String implements the fmt.Stringer interface.
+func (id Typed[P]) String() string { return id.id.String() }
+
+// Entity returns the name of the entity that is registered for the id's prefix.
+func (id Typed[P]) Entity() (string, bool) { return id.id.Entity() }
+
+// Proto returns the protobuf message for the id.
+func (id Typed[P]) Proto() *clidv1.CLID { return id.id.Proto() }
+
+// Value implements the driver Valuer interface.
+func (id Typed[P]) Value() (driver.Value, error) { return id.id.Value() }
+
+// MarshalJSON implements the json.Marshaler interface.
+func (id Typed[P]) MarshalJSON() ([]byte, error) { return id.id.MarshalJSON() }
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (id Typed[P]) MarshalText() ([]byte, error) { return id.id.MarshalText() }
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (id Typed[P]) MarshalBinary() ([]byte, error) { return id.id.MarshalBinary() }
+
+// MarshalGQL marshals the identifier.
+func (id Typed[P]) MarshalGQL(w io.Writer) { id.id.MarshalGQL(w) }
+
+// Scan implements the sql.Scanner.
+func (id *Typed[P]) Scan(v any) error {
+	return id.decode(func(sid *ID) error { return sid.Scan(v) })
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (id *Typed[P]) UnmarshalJSON(data []byte) error {
+	return id.decode(func(sid *ID) error { return sid.UnmarshalJSON(data) })
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface.
+func (id *Typed[P]) UnmarshalGQL(v interface{}) error {
+	return id.decode(func(sid *ID) error { return sid.UnmarshalGQL(v) })
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (id *Typed[P]) UnmarshalText(data []byte) error {
+	return id.decode(func(sid *ID) error { return sid.UnmarshalText(data) })
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (id *Typed[P]) UnmarshalBinary(data []byte) error {
+	return id.decode(func(sid *ID) error { return sid.UnmarshalBinary(data) })
+}
+
+// decode the untyped id and check the prefix, the id is only set when it is valid.
+func (id *Typed[P]) decode(fn func(sid *ID) error) error {
+	var (
+		sid ID
+		p   P
+	)
+
+	if err := fn(&sid); err != nil {
+		return err
+	}
+
+	// the zero value is encoded with the zero prefix, or none in binary
+	if (sid.p == ZeroPrefix || sid.p == "") && sid.d == (ulid.ULID{}) {
+		*id = Typed[P]{}
+
+		return nil
+	}
+
+	if sid.p != p.Prefix() {
+		return PrefixError{Expected: p.Prefix(), Actual: sid.p}
+	}
+
+	id.id = sid
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package clid_test
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/json"
+
+	"github.com/crewlinker/clgo/clid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type (
+	userPrefix struct{}
+	orgaPrefix struct{}
+)
+
+func (userPrefix) Prefix() string { return "user" }
+func (orgaPrefix) Prefix() string { return "orga" }
+
+var (
+	_ encoding.TextMarshaler     = clid.ID{}
+	_ encoding.TextUnmarshaler   = &clid.ID{}
+	_ encoding.BinaryMarshaler   = clid.ID{}
+	_ encoding.BinaryUnmarshaler = &clid.ID{}
+
+	_ encoding.TextMarshaler     = clid.Typed[userPrefix]{}
+	_ sql.Scanner                = &clid.Typed[userPrefix]{}
+	_ json.Unmarshaler           = &clid.Typed[userPrefix]{}
+	_ encoding.TextUnmarshaler   = &clid.Typed[userPrefix]{}
+	_ encoding.BinaryUnmarshaler = &clid.Typed[userPrefix]{}
+)
+
+var _ = Describe("encoding", func() {
+	It("should round trip text", func() {
+		id := clid.New("user")
+
+		data, err := id.MarshalText()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(id.String()))
+
+		var act clid.ID
+		Expect(act.UnmarshalText(data)).To(Succeed())
+		Expect(act).To(Equal(id))
+	})
+
+	It("should round trip binary", func() {
+		for _, id := range []clid.ID{clid.New("user"), {}} {
+			data, err := id.MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(HaveLen(clid.BinarySize))
+
+			var act clid.ID
+			Expect(act.UnmarshalBinary(data)).To(Succeed())
+			Expect(act).To(Equal(id))
+		}
+	})
+
+	It("should error on invalid binary size", func() {
+		var act clid.ID
+		Expect(act.UnmarshalBinary([]byte{1})).To(MatchError(ContainSubstring(`binary must be of size 20`)))
+	})
+})
+
+var _ = Describe("typed", func() {
+	It("should create with type prefix", func() {
+		id := clid.NewTyped[userPrefix]()
+		Expect(id.String()).To(HavePrefix("user-"))
+		Expect(id.Untyped().Prefix()).To(Equal("user"))
+	})
+
+	It("should parse and reject other prefix", func() {
+		id, err := clid.ParseTyped[userPrefix]("user-01HGWKKAWGABYZR1S1G9JMY5HZ")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id.String()).To(Equal("user-01HGWKKAWGABYZR1S1G9JMY5HZ"))
+
+		_, err = clid.ParseTyped[orgaPrefix]("user-01HGWKKAWGABYZR1S1G9JMY5HZ")
+		Expect(err).To(MatchError(clid.PrefixError{Expected: "orga", Actual: "user"}))
+	})
+
+	It("should reject other prefix on decoding", func() {
+		var id clid.Typed[orgaPrefix]
+		Expect(json.Unmarshal([]byte(`"user-01HGWKKAWGABYZR1S1G9JMY5HZ"`), &id)).
+			To(MatchError(`clid: unexpected prefix 'user', expected: 'orga'`))
+		Expect(id.UnmarshalGQL("user-01HGWKKAWGABYZR1S1G9JMY5HZ")).To(HaveOccurred())
+		Expect(id.UnmarshalText([]byte("user-01HGWKKAWGABYZR1S1G9JMY5HZ"))).To(HaveOccurred())
+		Expect(id).To(Equal(clid.Typed[orgaPrefix]{}))
+
+		data, err := clid.New("user").MarshalBinary()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id.UnmarshalBinary(data)).To(HaveOccurred())
+	})
+
+	It("should only type ids with the prefix", func() {
+		uid := clid.New("user")
+
+		id, err := clid.TypedFrom[userPrefix](uid)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id.Untyped()).To(Equal(uid))
+
+		_, err = clid.TypedFrom[orgaPrefix](uid)
+		Expect(err).To(MatchError(clid.PrefixError{Expected: "orga", Actual: "user"}))
+
+		Expect(clid.TypedFrom[orgaPrefix](clid.ID{})).To(Equal(clid.Typed[orgaPrefix]{}))
+	})
+
+	It("should round trip the zero value", func() {
+		var zero clid.Typed[userPrefix]
+
+		data, err := zero.MarshalText()
+		Expect(err).ToNot(HaveOccurred())
+
+		act := clid.NewTyped[userPrefix]()
+		Expect(act.UnmarshalText(data)).To(Succeed())
+		Expect(act).To(Equal(zero))
+
+		data, err = zero.MarshalBinary()
+		Expect(err).ToNot(HaveOccurred())
+
+		act = clid.NewTyped[userPrefix]()
+		Expect(act.UnmarshalBinary(data)).To(Succeed())
+		Expect(act).To(Equal(zero))
+	})
+
+	It("should json round trip in a struct", func() {
+		exp := struct{ ID clid.Typed[userPrefix] }{clid.NewTyped[userPrefix]()}
+		data, err := json.Marshal(exp)
+		Expect(err).ToNot(HaveOccurred())
+
+		var act struct{ ID clid.Typed[userPrefix] }
+		Expect(json.Unmarshal(data, &act)).To(Succeed())
+		Expect(act).To(Equal(exp))
+	})
+})
+
+var _ = Describe("registry", func() {
+	It("should register and lookup", func() {
+		clid.Register("rgst", "Registered")
+		Expect(func() { clid.Register("rgst", "Other") }).To(PanicWith(MatchRegexp(`already registered`)))
+		Expect(func() { clid.Register("a", "Other") }).To(PanicWith(MatchRegexp(`prefix size`)))
+
+		entity, ok := clid.New("rgst").Entity()
+		Expect(ok).To(BeTrue())
+		Expect(entity).To(Equal("Registered"))
+
+		_, ok = clid.Entity("nope")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,166 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        (unknown)
+// source: clid/v1/clid.proto
+
+package clidv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CLID is a prefixed ULID identifier, encoded as the prefix, a separator and the ULID. For
+// example: "user-01HGWKKAWGABYZR1S1G9JMY5HZ". Fields of this type can restrict the prefix with
+// a field-level rule: (buf.validate.field).cel.expression = "this.value.startsWith('user-')".
+type CLID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// value holds the string encoding of the identifier
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *CLID) Reset() {
+	*x = CLID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clid_v1_clid_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CLID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CLID) ProtoMessage() {}
+
+func (x *CLID) ProtoReflect() protoreflect.Message {
+	mi := &file_clid_v1_clid_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CLID.ProtoReflect.Descriptor instead.
+func (*CLID) Descriptor() ([]byte, []int) {
+	return file_clid_v1_clid_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CLID) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_clid_v1_clid_proto protoreflect.FileDescriptor
+
+var file_clid_v1_clid_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x63, 0x6c, 0x69, 0x64, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6c, 0x69, 0x64, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x63, 0x6c, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x62,
+	0x75, 0x66, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc4, 0x01, 0x0a, 0x04, 0x43,
+	0x4c, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0xa5, 0x01, 0xba, 0x48, 0xa1, 0x01,
+	0x1a, 0x9e, 0x01, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x64, 0x2e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x12, 0x3e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x20, 0x6d, 0x75, 0x73, 0x74, 0x20, 0x62, 0x65, 0x20,
+	0x61, 0x20, 0x34, 0x20, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x20, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x2c, 0x20, 0x61, 0x20, 0x27, 0x2d, 0x27, 0x20, 0x73, 0x65, 0x70, 0x61,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x61, 0x20, 0x55, 0x4c, 0x49, 0x44,
+	0x1a, 0x4f, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x73, 0x28, 0x27, 0x5e, 0x5b, 0x61, 0x2d, 0x7a, 0x41, 0x2d, 0x5a, 0x30, 0x2d,
+	0x39, 0x5d, 0x7b, 0x34, 0x7d, 0x2d, 0x5b, 0x30, 0x2d, 0x37, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x41,
+	0x2d, 0x48, 0x4a, 0x4b, 0x4d, 0x4e, 0x50, 0x2d, 0x54, 0x56, 0x2d, 0x5a, 0x61, 0x2d, 0x68, 0x6a,
+	0x6b, 0x6d, 0x6e, 0x70, 0x2d, 0x74, 0x76, 0x2d, 0x7a, 0x5d, 0x7b, 0x32, 0x35, 0x7d, 0x24, 0x27,
+	0x29, 0x42, 0x80, 0x01, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6c, 0x69, 0x64, 0x2e, 0x76,
+	0x31, 0x42, 0x09, 0x43, 0x6c, 0x69, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x29,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x72, 0x65, 0x77, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x67, 0x6f, 0x2f, 0x63, 0x6c, 0x69, 0x64, 0x2f,
+	0x76, 0x31, 0x3b, 0x63, 0x6c, 0x69, 0x64, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x58, 0x58, 0xaa,
+	0x02, 0x07, 0x43, 0x6c, 0x69, 0x64, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x07, 0x43, 0x6c, 0x69, 0x64,
+	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x13, 0x43, 0x6c, 0x69, 0x64, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50,
+	0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x08, 0x43, 0x6c, 0x69, 0x64,
+	0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_clid_v1_clid_proto_rawDescOnce sync.Once
+	file_clid_v1_clid_proto_rawDescData = file_clid_v1_clid_proto_rawDesc
+)
+
+func file_clid_v1_clid_proto_rawDescGZIP() []byte {
+	file_clid_v1_clid_proto_rawDescOnce.Do(func() {
+		file_clid_v1_clid_proto_rawDescData = protoimpl.X.CompressGZIP(file_clid_v1_clid_proto_rawDescData)
+	})
+	return file_clid_v1_clid_proto_rawDescData
+}
+
+var file_clid_v1_clid_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_clid_v1_clid_proto_goTypes = []interface{}{
+	(*CLID)(nil), // 0: clid.v1.CLID
+}
+var file_clid_v1_clid_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_clid_v1_clid_proto_init() }
+func file_clid_v1_clid_proto_init() {
+	if File_clid_v1_clid_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_clid_v1_clid_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CLID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_clid_v1_clid_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_clid_v1_clid_proto_goTypes,
+		DependencyIndexes: file_clid_v1_clid_proto_depIdxs,
+		MessageInfos:      file_clid_v1_clid_proto_msgTypes,
+	}.Build()
+	File_clid_v1_clid_proto = out.File
+	file_clid_v1_clid_proto_rawDesc = nil
+	file_clid_v1_clid_proto_goTypes = nil
+	file_clid_v1_clid_proto_depIdxs = nil
+}
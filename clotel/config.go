@@ -6,11 +6,58 @@ import "time"
 type Config struct {
 	// DetectorDetectTimeout bound the time it may take to init a trace provider
 	DetectorDetectTimeout time.Duration `env:"DETECTOR_DETECT_TIMEOUT" envDefault:"1s"`
+	// ResourceDetectors selects the detectors whose resources are merged, in order: ecs, lambda, ec2, env, host
+	// and process. The "env" detector reads OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME
+	ResourceDetectors []string `env:"RESOURCE_DETECTORS" envDefault:"ecs,env"`
+	// Propagators selects the propagators of the trace context and baggage: tracecontext, baggage and xray. The
+	// X-Ray id generator, and X-Ray formatting of trace ids in the logs, are only used with the xray propagator
+	Propagators []string `env:"PROPAGATORS" envDefault:"tracecontext,baggage,xray"`
+	// TracesSampler selects the sampler: always_on, always_off, traceidratio or ratelimiting. With the
+	// "parentbased_" prefix the sampler is only used for root spans, other spans follow their parent
+	TracesSampler string `env:"TRACES_SAMPLER" envDefault:"parentbased_always_on"`
+	// TracesSamplerRatio configures the ratio of traces that is sampled by the traceidratio sampler
+	TracesSamplerRatio float64 `env:"TRACES_SAMPLER_RATIO" envDefault:"1"`
+	// TracesSamplerRate configures the maximum number of traces per second that the ratelimiting sampler samples
+	TracesSamplerRate float64 `env:"TRACES_SAMPLER_RATE" envDefault:"100"`
 	// ExporterTimeout overwrites the timeout for exporting spans. This can be useful in tests to speed
 	// them up
 	ExporterTimeout time.Duration `env:"EXPORTER_TIMEOUT" envDefault:"10s"`
 	// ExporterEndpoint configures where otel span exporter will send data to
 	ExporterEndpoint string `env:"EXPORTER_ENDPOINT" envDefault:"localhost:4317"`
+	// ExporterProtocol selects the protocol for exporting to the collector: grpc or http/protobuf
+	ExporterProtocol string `env:"EXPORTER_PROTOCOL" envDefault:"grpc"`
+	// ExporterInsecure disables TLS when exporting, the collector is usually a sidecar on localhost
+	ExporterInsecure bool `env:"EXPORTER_INSECURE" envDefault:"true"`
+	// ExporterCAFile configures a PEM file with certificates that are trusted to verify the collector, in addition
+	// to the system's certificates
+	ExporterCAFile string `env:"EXPORTER_CA_FILE"`
+	// ExporterHeaders are sent along with every export, e.g. to authenticate with a vendor's API key
+	ExporterHeaders map[string]string `env:"EXPORTER_HEADERS"`
+	// ExporterCompression configures the compression of exports: none or gzip
+	ExporterCompression string `env:"EXPORTER_COMPRESSION" envDefault:"none"`
+	// ExporterRetryEnabled enables retrying exports that failed
+	ExporterRetryEnabled bool `env:"EXPORTER_RETRY_ENABLED" envDefault:"true"`
+	// ExporterRetryInitialInterval configures how long to wait before the first retry
+	ExporterRetryInitialInterval time.Duration `env:"EXPORTER_RETRY_INITIAL_INTERVAL" envDefault:"5s"`
+	// ExporterRetryMaxInterval configures the maximum time between retries
+	ExporterRetryMaxInterval time.Duration `env:"EXPORTER_RETRY_MAX_INTERVAL" envDefault:"30s"`
+	// ExporterRetryMaxElapsedTime configures how long an export is retried before it is dropped
+	ExporterRetryMaxElapsedTime time.Duration `env:"EXPORTER_RETRY_MAX_ELAPSED_TIME" envDefault:"1m"`
+	// EnableOTLPLogs also exports the zap logs to the collector, next to the traces and metrics
+	EnableOTLPLogs bool `env:"ENABLE_OTLP_LOGS" envDefault:"false"`
 	// MetricExporterConnectTimeout configures how long we'll wait for het metric exporter to connect to the collector
 	MetricExporterConnectTimeout time.Duration `env:"METRIC_EXPORTER_CONNECT_TIMEOUT" envDefault:"1s"`
+	// DisableOTLPMetrics disables exporting metrics to the collector, e.g. when they are scraped by Prometheus instead
+	DisableOTLPMetrics bool `env:"DISABLE_OTLP_METRICS" envDefault:"false"`
+	// MetricsExemplarFilter selects which measurements are recorded as exemplars, to link the data points of the
+	// (histogram) metrics to traces: trace_based, always_on or always_off
+	MetricsExemplarFilter string `env:"METRICS_EXEMPLAR_FILTER" envDefault:"trace_based"`
+	// DisableRuntimeMetrics disables the Go runtime metrics that are registered by default
+	DisableRuntimeMetrics bool `env:"DISABLE_RUNTIME_METRICS" envDefault:"false"`
+	// RuntimeMetricsInterval configures how often the runtime's memory statistics are read at most
+	RuntimeMetricsInterval time.Duration `env:"RUNTIME_METRICS_INTERVAL" envDefault:"15s"`
+	// PrometheusBindAddrPort serves the Prometheus metrics on their own listener, when configured
+	PrometheusBindAddrPort string `env:"PROMETHEUS_BIND_ADDR_PORT"`
+	// PrometheusPath configures the path where the Prometheus metrics are served on their own listener
+	PrometheusPath string `env:"PROMETHEUS_PATH" envDefault:"/metrics"`
 }
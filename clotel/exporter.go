@@ -0,0 +1,260 @@
+package clotel
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"google.golang.org/grpc/credentials"
+)
+
+// ErrNoCACertificates is returned when the exporter's CA file holds no certificates.
+var ErrNoCACertificates = errors.New("clotel: no certificates in CA file")
+
+// exporter holds the settings that are shared by the exporters of all signals.
+type exporter struct {
+	cfg  Config
+	tls  *tls.Config
+	gzip bool
+}
+
+// newExporter validates the configuration that is shared by the exporters.
+func newExporter(cfg Config) (*exporter, error) {
+	exp := &exporter{cfg: cfg}
+
+	switch cfg.ExporterProtocol {
+	case "grpc", "http/protobuf":
+	default:
+		return nil, fmt.Errorf("unsupported exporter protocol: '%s'", cfg.ExporterProtocol) //nolint:goerr113
+	}
+
+	switch cfg.ExporterCompression {
+	case "gzip":
+		exp.gzip = true
+	case "none":
+	default:
+		return nil, fmt.Errorf("unsupported exporter compression: '%s'", cfg.ExporterCompression) //nolint:goerr113
+	}
+
+	if cfg.ExporterInsecure {
+		return exp, nil
+	}
+
+	// verify the collector with the system's certificates, and those in the CA file
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if cfg.ExporterCAFile != "" {
+		pem, err := os.ReadFile(cfg.ExporterCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, ErrNoCACertificates
+		}
+	}
+
+	exp.tls = &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}
+
+	return exp, nil
+}
+
+// grpc returns whether the grpc protocol is used, else it is http/protobuf.
+func (e *exporter) grpc() bool { return e.cfg.ExporterProtocol == "grpc" }
+
+// retryConfig has the same fields as the retry config of each exporter package, so it can be converted to them.
+type retryConfig struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+// retry returns the configured retry config.
+func (e *exporter) retry() retryConfig {
+	return retryConfig{
+		Enabled:         e.cfg.ExporterRetryEnabled,
+		InitialInterval: e.cfg.ExporterRetryInitialInterval,
+		MaxInterval:     e.cfg.ExporterRetryMaxInterval,
+		MaxElapsedTime:  e.cfg.ExporterRetryMaxElapsedTime,
+	}
+}
+
+// newTraceExporter returns the unstarted span exporter.
+func newTraceExporter(cfg Config) (*otlptrace.Exporter, error) {
+	e, err := newExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.grpc() {
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(cfg.ExporterEndpoint),
+			otlptracegrpc.WithTimeout(cfg.ExporterTimeout),
+			otlptracegrpc.WithHeaders(cfg.ExporterHeaders),
+			otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig(e.retry())),
+		}
+
+		if e.tls == nil {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(e.tls)))
+		}
+
+		if e.gzip {
+			opts = append(opts, otlptracegrpc.WithCompressor("gzip"))
+		}
+
+		return otlptracegrpc.NewUnstarted(opts...), nil
+	}
+
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.ExporterEndpoint),
+		otlptracehttp.WithTimeout(cfg.ExporterTimeout),
+		otlptracehttp.WithHeaders(cfg.ExporterHeaders),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig(e.retry())),
+	}
+
+	if e.tls == nil {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	} else {
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(e.tls))
+	}
+
+	if e.gzip {
+		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+
+	return otlptracehttp.NewUnstarted(opts...), nil
+}
+
+// NewMetricExporter inits a metric exporter.
+func NewMetricExporter(cfg Config) (metric.Exporter, error) {
+	e, err := newExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.MetricExporterConnectTimeout)
+	defer cancel()
+
+	var exp metric.Exporter
+
+	if e.grpc() {
+		opts := []otlpmetricgrpc.Option{
+			otlpmetricgrpc.WithEndpoint(cfg.ExporterEndpoint),
+			otlpmetricgrpc.WithTimeout(cfg.ExporterTimeout),
+			otlpmetricgrpc.WithHeaders(cfg.ExporterHeaders),
+			otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig(e.retry())),
+		}
+
+		if e.tls == nil {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(e.tls)))
+		}
+
+		if e.gzip {
+			opts = append(opts, otlpmetricgrpc.WithCompressor("gzip"))
+		}
+
+		exp, err = otlpmetricgrpc.New(ctx, opts...)
+	} else {
+		opts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(cfg.ExporterEndpoint),
+			otlpmetrichttp.WithTimeout(cfg.ExporterTimeout),
+			otlpmetrichttp.WithHeaders(cfg.ExporterHeaders),
+			otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig(e.retry())),
+		}
+
+		if e.tls == nil {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		} else {
+			opts = append(opts, otlpmetrichttp.WithTLSClientConfig(e.tls))
+		}
+
+		if e.gzip {
+			opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+		}
+
+		exp, err = otlpmetrichttp.New(ctx, opts...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to init exporter: %w", err)
+	}
+
+	return exp, nil
+}
+
+// NewLogExporter inits a log exporter.
+func NewLogExporter(cfg Config) (sdklog.Exporter, error) {
+	e, err := newExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var exp sdklog.Exporter
+
+	if e.grpc() {
+		opts := []otlploggrpc.Option{
+			otlploggrpc.WithEndpoint(cfg.ExporterEndpoint),
+			otlploggrpc.WithTimeout(cfg.ExporterTimeout),
+			otlploggrpc.WithHeaders(cfg.ExporterHeaders),
+			otlploggrpc.WithRetry(otlploggrpc.RetryConfig(e.retry())),
+		}
+
+		if e.tls == nil {
+			opts = append(opts, otlploggrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(e.tls)))
+		}
+
+		if e.gzip {
+			opts = append(opts, otlploggrpc.WithCompressor("gzip"))
+		}
+
+		exp, err = otlploggrpc.New(context.Background(), opts...)
+	} else {
+		opts := []otlploghttp.Option{
+			otlploghttp.WithEndpoint(cfg.ExporterEndpoint),
+			otlploghttp.WithTimeout(cfg.ExporterTimeout),
+			otlploghttp.WithHeaders(cfg.ExporterHeaders),
+			otlploghttp.WithRetry(otlploghttp.RetryConfig(e.retry())),
+		}
+
+		if e.tls == nil {
+			opts = append(opts, otlploghttp.WithInsecure())
+		} else {
+			opts = append(opts, otlploghttp.WithTLSClientConfig(e.tls))
+		}
+
+		if e.gzip {
+			opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+		}
+
+		exp, err = otlploghttp.New(context.Background(), opts...)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to init exporter: %w", err)
+	}
+
+	return exp, nil
+}
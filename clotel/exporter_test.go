@@ -0,0 +1,286 @@
+package clotel_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/crewlinker/clgo/clotel"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	collogs "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// receiver is a stand-in for the OTLP collector, it records what is exported to it.
+type receiver struct {
+	coltrace.UnimplementedTraceServiceServer
+	colmetrics.UnimplementedMetricsServiceServer
+	collogs.UnimplementedLogsServiceServer
+
+	mu       sync.Mutex
+	spans    []string
+	metrics  []string
+	logs     []string
+	apiKeys  []string
+	encoding []string
+}
+
+func (rcv *receiver) record(apiKey, encoding string, msg proto.Message) {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	rcv.apiKeys, rcv.encoding = append(rcv.apiKeys, apiKey), append(rcv.encoding, encoding)
+
+	switch msg := msg.(type) {
+	case *coltrace.ExportTraceServiceRequest:
+		for _, rs := range msg.GetResourceSpans() {
+			for _, ss := range rs.GetScopeSpans() {
+				for _, s := range ss.GetSpans() {
+					rcv.spans = append(rcv.spans, s.GetName())
+				}
+			}
+		}
+	case *colmetrics.ExportMetricsServiceRequest:
+		for _, rm := range msg.GetResourceMetrics() {
+			for _, sm := range rm.GetScopeMetrics() {
+				for _, m := range sm.GetMetrics() {
+					rcv.metrics = append(rcv.metrics, m.GetName())
+				}
+			}
+		}
+	case *collogs.ExportLogsServiceRequest:
+		for _, rl := range msg.GetResourceLogs() {
+			for _, sl := range rl.GetScopeLogs() {
+				for _, l := range sl.GetLogRecords() {
+					rcv.logs = append(rcv.logs, l.GetBody().GetStringValue())
+				}
+			}
+		}
+	}
+}
+
+// ServeHTTP implements the http/protobuf protocol.
+func (rcv *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req, resp proto.Message
+
+	switch r.URL.Path {
+	case "/v1/traces":
+		req, resp = &coltrace.ExportTraceServiceRequest{}, &coltrace.ExportTraceServiceResponse{}
+	case "/v1/metrics":
+		req, resp = &colmetrics.ExportMetricsServiceRequest{}, &colmetrics.ExportMetricsServiceResponse{}
+	case "/v1/logs":
+		req, resp = &collogs.ExportLogsServiceRequest{}, &collogs.ExportLogsServiceResponse{}
+	default:
+		http.NotFound(w, r)
+
+		return
+	}
+
+	body := r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		body = gzr
+	}
+
+	data, err := io.ReadAll(body)
+	if err == nil {
+		err = proto.Unmarshal(data, req)
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	rcv.record(r.Header.Get("X-Api-Key"), r.Header.Get("Content-Encoding"), req)
+
+	data, _ = proto.Marshal(resp)
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, _ = w.Write(data)
+}
+
+func (rcv *receiver) recordGrpc(ctx context.Context, msg proto.Message) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	rcv.record(strings.Join(md.Get("x-api-key"), ","), strings.Join(md.Get("grpc-encoding"), ","), msg)
+}
+
+func (rcv *receiver) Export(
+	ctx context.Context, req *coltrace.ExportTraceServiceRequest,
+) (*coltrace.ExportTraceServiceResponse, error) {
+	rcv.recordGrpc(ctx, req)
+
+	return &coltrace.ExportTraceServiceResponse{}, nil
+}
+
+// metricsServer and logsServer implement the other services, their Export methods clash with the trace service's.
+type (
+	metricsServer struct{ *receiver }
+	logsServer    struct{ *receiver }
+)
+
+func (s metricsServer) Export(
+	ctx context.Context, req *colmetrics.ExportMetricsServiceRequest,
+) (*colmetrics.ExportMetricsServiceResponse, error) {
+	s.recordGrpc(ctx, req)
+
+	return &colmetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func (s logsServer) Export(
+	ctx context.Context, req *collogs.ExportLogsServiceRequest,
+) (*collogs.ExportLogsServiceResponse, error) {
+	s.recordGrpc(ctx, req)
+
+	return &collogs.ExportLogsServiceResponse{}, nil
+}
+
+// exportAll starts the app with the environment, emits telemetry of all signals and stops it to flush them.
+func exportAll(ctx context.Context, env map[string]string) {
+	GinkgoHelper()
+
+	for k, v := range env {
+		os.Setenv(k, v)
+		DeferCleanup(os.Unsetenv, k)
+	}
+
+	var (
+		trp  *sdktrace.TracerProvider
+		mtp  *sdkmetric.MeterProvider
+		logs *zap.Logger
+	)
+
+	app := fx.New(fx.Populate(&trp, &mtp, &logs), clotel.ServiceProvide(), clzap.TestProvide())
+	Expect(app.Start(ctx)).To(Succeed())
+
+	_, span := trp.Tracer("test").Start(ctx, "my-span")
+	span.End()
+
+	ctr, err := mtp.Meter("test").Int64Counter("some.counter")
+	Expect(err).ToNot(HaveOccurred())
+	ctr.Add(ctx, 1)
+
+	logs.Info("my-log")
+
+	Expect(app.Stop(ctx)).To(Succeed())
+}
+
+var _ = Describe("otlp exporting", func() {
+	var rcv *receiver
+	BeforeEach(func() {
+		rcv = &receiver{}
+	})
+
+	It("should export over http/protobuf with tls, headers and compression", func(ctx context.Context) {
+		srv := httptest.NewTLSServer(rcv)
+		DeferCleanup(srv.Close)
+
+		caFile := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+		Expect(os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+			Type: "CERTIFICATE", Bytes: srv.Certificate().Raw,
+		}), 0o600)).To(Succeed())
+
+		exportAll(ctx, map[string]string{
+			"CLOTEL_EXPORTER_PROTOCOL":    "http/protobuf",
+			"CLOTEL_EXPORTER_ENDPOINT":    srv.Listener.Addr().String(),
+			"CLOTEL_EXPORTER_INSECURE":    "false",
+			"CLOTEL_EXPORTER_CA_FILE":     caFile,
+			"CLOTEL_EXPORTER_HEADERS":     "x-api-key:secret",
+			"CLOTEL_EXPORTER_COMPRESSION": "gzip",
+			"CLOTEL_ENABLE_OTLP_LOGS":     "true",
+		})
+
+		rcv.mu.Lock()
+		defer rcv.mu.Unlock()
+
+		Expect(rcv.spans).To(ContainElement("my-span"))
+		Expect(rcv.metrics).To(ContainElement("some.counter"))
+		Expect(rcv.logs).To(ContainElement("my-log"))
+		Expect(rcv.apiKeys).To(HaveEach("secret"))
+		Expect(rcv.encoding).To(HaveEach("gzip"))
+	})
+
+	It("should export over grpc with headers", func(ctx context.Context) {
+		ln, err := (&net.ListenConfig{}).Listen(ctx, "tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+
+		srv := grpc.NewServer()
+		coltrace.RegisterTraceServiceServer(srv, rcv)
+		colmetrics.RegisterMetricsServiceServer(srv, metricsServer{rcv})
+		collogs.RegisterLogsServiceServer(srv, logsServer{rcv})
+
+		go srv.Serve(ln)
+		DeferCleanup(srv.Stop)
+
+		exportAll(ctx, map[string]string{
+			"CLOTEL_EXPORTER_ENDPOINT": ln.Addr().String(),
+			"CLOTEL_EXPORTER_HEADERS":  "x-api-key:secret",
+			"CLOTEL_ENABLE_OTLP_LOGS":  "true",
+		})
+
+		rcv.mu.Lock()
+		defer rcv.mu.Unlock()
+
+		Expect(rcv.spans).To(ContainElement("my-span"))
+		Expect(rcv.metrics).To(ContainElement("some.counter"))
+		Expect(rcv.logs).To(ContainElement("my-log"))
+		Expect(rcv.apiKeys).To(HaveEach("secret"))
+	})
+
+	It("should not export logs by default", func(ctx context.Context) {
+		srv := httptest.NewServer(rcv)
+		DeferCleanup(srv.Close)
+
+		exportAll(ctx, map[string]string{
+			"CLOTEL_EXPORTER_PROTOCOL": "http/protobuf",
+			"CLOTEL_EXPORTER_ENDPOINT": srv.Listener.Addr().String(),
+		})
+
+		rcv.mu.Lock()
+		defer rcv.mu.Unlock()
+
+		Expect(rcv.spans).To(ContainElement("my-span"))
+		Expect(rcv.logs).To(BeEmpty())
+	})
+})
+
+var _ = Describe("exporter config", func() {
+	It("should reject an unsupported protocol", func() {
+		_, err := clotel.NewMetricExporter(clotel.Config{ExporterProtocol: "http/json", ExporterCompression: "none"})
+		Expect(err).To(MatchError(ContainSubstring("unsupported exporter protocol")))
+	})
+
+	It("should reject a CA file without certificates", func() {
+		caFile := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+		Expect(os.WriteFile(caFile, []byte("foo"), 0o600)).To(Succeed())
+
+		_, err := clotel.NewLogExporter(clotel.Config{
+			ExporterProtocol: "grpc", ExporterCompression: "none", ExporterCAFile: caFile,
+		})
+		Expect(err).To(MatchError(clotel.ErrNoCACertificates))
+	})
+})
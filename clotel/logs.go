@@ -0,0 +1,70 @@
+package clotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crewlinker/clgo/clzap"
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.uber.org/fx"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewLoggerProvider inits a logger provider that batches the log records to the exporter.
+func NewLoggerProvider(cfg Config, det resource.Detector, exp sdklog.Exporter) (*sdklog.LoggerProvider, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DetectorDetectTimeout)
+	defer cancel()
+
+	res, err := det.Detect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect resource: %w", err)
+	}
+
+	return sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exp)),
+	), nil
+}
+
+// newLogCore bridges the zap logs to the collector with an otelzap core, if it is enabled. The core only receives
+// the logs of the configured level. The logger provider depends on the resource detector, not on the logger, so
+// the core can be teed into the logger itself.
+func newLogCore(
+	cfg Config,
+	lc fx.Lifecycle,
+	det resource.Detector,
+	lvl zapcore.LevelEnabler,
+) (*clzap.SecondaryCore, error) {
+	if !cfg.EnableOTLPLogs {
+		return nil, nil //nolint:nilnil
+	}
+
+	exp, err := NewLogExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	lp, err := NewLoggerProvider(cfg, det, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	// the logger is synced before the provider is shut down, since the hook is appended before the logger's
+	lc.Append(fx.Hook{OnStop: func(ctx context.Context) error {
+		if err := lp.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to shutdown: %w", err)
+		}
+
+		return nil
+	}})
+
+	core, err := zapcore.NewIncreaseLevelCore(
+		otelzap.NewCore("github.com/crewlinker/clgo/clzap", otelzap.WithLoggerProvider(lp)), lvl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init level core: %w", err)
+	}
+
+	return &clzap.SecondaryCore{Core: core, Name: "otlp"}, nil
+}
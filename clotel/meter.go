@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
-// NewMeterProvider initializes otel provider for metrics throughout the application.
-func NewMeterProvider(cfg Config, det resource.Detector, mtr metric.Reader) (*metric.MeterProvider, error) {
+// NewMeterProvider initializes otel provider for metrics throughout the application. Metrics are read by the
+// reader, and by any other readers that are provided through the value group (such as the Prometheus exporter).
+func NewMeterProvider(
+	cfg Config,
+	det resource.Detector,
+	mtr metric.Reader,
+	readers []metric.Reader,
+) (*metric.MeterProvider, error) {
 	// detect the resource with a timeout
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.DetectorDetectTimeout)
 	defer cancel()
@@ -21,9 +28,19 @@ func NewMeterProvider(cfg Config, det resource.Detector, mtr metric.Reader) (*me
 		return nil, fmt.Errorf("failed to detect resource: %w", err)
 	}
 
-	mtp := metric.NewMeterProvider(
-		metric.WithReader(mtr),
-		metric.WithResource(res))
+	filter, err := newExemplarFilter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []metric.Option{metric.WithResource(res), metric.WithExemplarFilter(filter)}
+	for _, r := range append([]metric.Reader{mtr}, readers...) {
+		if r != nil {
+			opts = append(opts, metric.WithReader(r))
+		}
+	}
+
+	mtp := metric.NewMeterProvider(opts...)
 
 	// set globally in case libraries don't allow injecting
 	otel.SetMeterProvider(mtp)
@@ -31,16 +48,47 @@ func NewMeterProvider(cfg Config, det resource.Detector, mtr metric.Reader) (*me
 	return mtp, nil
 }
 
-// NewMetricExporter inits a metric exporter.
-func NewMetricExporter(cfg Config) (metric.Exporter, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.MetricExporterConnectTimeout)
-	defer cancel()
+// newExemplarFilter returns the configured exemplar filter. The trace based filter only records measurements that
+// are made in the context of a sampled span, so the exemplar links to a trace that is exported.
+func newExemplarFilter(cfg Config) (exemplar.Filter, error) {
+	switch cfg.MetricsExemplarFilter {
+	case "trace_based":
+		return exemplar.TraceBasedFilter, nil
+	case "always_on":
+		return exemplar.AlwaysOnFilter, nil
+	case "always_off":
+		return exemplar.AlwaysOffFilter, nil
+	default:
+		return nil, fmt.Errorf("unsupported exemplar filter: '%s'", cfg.MetricsExemplarFilter) //nolint:goerr113
+	}
+}
 
-	exp, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithInsecure(),
-		otlpmetricgrpc.WithEndpoint(cfg.ExporterEndpoint))
+// newOTLPMetricReaders inits the periodic reader that exports metrics to the collector, unless it is disabled.
+func newOTLPMetricReaders(cfg Config) ([]metric.Reader, error) {
+	if cfg.DisableOTLPMetrics {
+		return nil, nil
+	}
+
+	exp, err := NewMetricExporter(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to init exporter: %w", err)
+		return nil, err
+	}
+
+	return []metric.Reader{metric.NewPeriodicReader(exp)}, nil
+}
+
+// startRuntimeMetrics registers the Go runtime metrics with the meter provider, unless it is disabled.
+func startRuntimeMetrics(cfg Config, mtp *metric.MeterProvider) error {
+	if cfg.DisableRuntimeMetrics {
+		return nil
+	}
+
+	if err := runtime.Start(
+		runtime.WithMeterProvider(mtp),
+		runtime.WithMinimumReadMemStatsInterval(cfg.RuntimeMetricsInterval),
+	); err != nil {
+		return fmt.Errorf("failed to start runtime metrics: %w", err)
 	}
 
-	return exp, nil
+	return nil
 }
@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/fx"
 )
 
@@ -17,8 +18,9 @@ var _ = Describe("otel metrics", func() {
 	var mtp *sdkmetric.MeterProvider
 	var mpi metric.MeterProvider
 	var mtr sdkmetric.Reader
+	var trp *sdktrace.TracerProvider
 	BeforeEach(func(ctx context.Context) {
-		app := fx.New(fx.Populate(&mtp, &mpi, &mtr), clotel.TestProvide(), clzap.TestProvide())
+		app := fx.New(fx.Populate(&mtp, &mpi, &mtr, &trp), clotel.TestProvide(), clzap.TestProvide())
 		Expect(app.Start(ctx)).To(Succeed())
 		DeferCleanup(app.Stop)
 	})
@@ -33,9 +35,54 @@ var _ = Describe("otel metrics", func() {
 		err = mtr.Collect(ctx, &mrm)
 		Expect(err).ToNot(HaveOccurred())
 
-		Expect(mrm.ScopeMetrics[0].Scope.Name).To(Equal("some_test"))
-		Expect(mrm.ScopeMetrics[0].Metrics[0].Name).To(Equal("some.counter"))
-		sum, _ := mrm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+		sm := scopeMetrics(mrm, "some_test")
+		Expect(sm.Metrics[0].Name).To(Equal("some.counter"))
+		sum, _ := sm.Metrics[0].Data.(metricdata.Sum[int64])
 		Expect(sum.DataPoints[0].Value).To(Equal(int64(110)))
 	})
+
+	It("should register runtime metrics", func(ctx context.Context) {
+		mrm := metricdata.ResourceMetrics{}
+		Expect(mtr.Collect(ctx, &mrm)).To(Succeed())
+
+		sm := scopeMetrics(mrm, "go.opentelemetry.io/contrib/instrumentation/runtime")
+		Expect(sm.Metrics).To(ContainElement(HaveField("Name", "process.runtime.go.goroutines")))
+	})
+
+	It("should link histogram data points to the trace", func(ctx context.Context) {
+		hist, err := mtp.Meter("some_test").Float64Histogram("some.duration")
+		Expect(err).ToNot(HaveOccurred())
+
+		sctx, span := trp.Tracer("some_test").Start(ctx, "my-span")
+		hist.Record(sctx, 1.5)
+		span.End()
+
+		hist.Record(ctx, 2.5) // not in a trace, so no exemplar
+
+		mrm := metricdata.ResourceMetrics{}
+		Expect(mtr.Collect(ctx, &mrm)).To(Succeed())
+
+		data, _ := scopeMetrics(mrm, "some_test").Metrics[0].Data.(metricdata.Histogram[float64])
+		Expect(data.DataPoints).To(HaveLen(1))
+		Expect(data.DataPoints[0].Exemplars).To(HaveLen(1))
+
+		tid := span.SpanContext().TraceID()
+		Expect(data.DataPoints[0].Exemplars[0].TraceID).To(Equal(tid[:]))
+		Expect(data.DataPoints[0].Exemplars[0].Value).To(Equal(1.5))
+	})
 })
+
+// scopeMetrics returns the metrics of the instrumentation scope.
+func scopeMetrics(mrm metricdata.ResourceMetrics, name string) (sm metricdata.ScopeMetrics) {
+	GinkgoHelper()
+
+	for _, sm = range mrm.ScopeMetrics {
+		if sm.Scope.Name == name {
+			return sm
+		}
+	}
+
+	Fail("no metrics for scope: " + name)
+
+	return sm
+}
@@ -7,11 +7,9 @@ import (
 	"strings"
 
 	"github.com/crewlinker/clgo/clconfig"
-	"go.opentelemetry.io/contrib/detectors/aws/ecs"
-	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"github.com/crewlinker/clgo/clzap"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/metric"
-	"go.opentelemetry.io/otel/propagation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -32,14 +30,12 @@ func Provide() fx.Option {
 		clconfig.Provide[Config](strings.ToUpper(moduleName)+"_"),
 		// the incoming logger will be named after the module
 		fx.Decorate(func(l *zap.Logger) *zap.Logger { return l.Named(moduleName) }),
-		// we can use the xray id generator in all cases
-		fx.Provide(fx.Annotate(xray.NewIDGenerator, fx.As(new(sdktrace.IDGenerator)))),
-		// we also provide an xray propagator for anywhere it code we need this
-		fx.Provide(func() propagation.TextMapPropagator {
-			xp := xray.Propagator{}
-
-			return xp
-		}),
+		// provide the xray id generator, if the xray propagator is configured
+		fx.Provide(NewIDGenerator),
+		// provide the configured propagators, for anywhere in code we need them
+		fx.Provide(NewPropagator),
+		// provide the configured sampler
+		fx.Provide(NewSampler),
 		// provide the tracer provider
 		fx.Provide(fx.Annotate(NewTracerProvider,
 			fx.OnStop(func(ctx context.Context, tp *sdktrace.TracerProvider) error {
@@ -52,18 +48,29 @@ func Provide() fx.Option {
 		)),
 		// also provide as more generic interface
 		fx.Provide(func(tp *sdktrace.TracerProvider) trace.TracerProvider { return tp }),
-		// provide the metrer provider
-		fx.Provide(fx.Annotate(NewMeterProvider)),
+		// provide the metrer provider, with the (optional) reader and any additional readers
+		fx.Provide(fx.Annotate(NewMeterProvider,
+			fx.ParamTags(``, ``, `optional:"true"`, `group:"`+metricReadersGroup+`"`),
+			fx.OnStop(func(ctx context.Context, mp *sdkmetric.MeterProvider) error {
+				if err := mp.Shutdown(ctx); err != nil {
+					return fmt.Errorf("failed to shutdown: %w", err)
+				}
+
+				return nil
+			}),
+		)),
 		// also provide as more generic interface
 		fx.Provide(func(mp *sdkmetric.MeterProvider) metric.MeterProvider { return mp }),
+		// register the runtime metrics by default
+		fx.Invoke(startRuntimeMetrics),
 	)
 }
 
 // ServiceProvide provides otel dependencies for container services.
 func ServiceProvide() fx.Option {
 	return fx.Options(Provide(),
-		// service will export traces over grpc
-		fx.Provide(fx.Annotate(newGrpcExporter,
+		// service will export traces with the configured protocol
+		fx.Provide(fx.Annotate(newTraceExporter,
 			fx.OnStart(func(ctx context.Context, e *otlptrace.Exporter) error {
 				if err := e.Start(ctx); err != nil {
 					return fmt.Errorf("failed to start: %w", err)
@@ -79,16 +86,15 @@ func ServiceProvide() fx.Option {
 				return nil
 			}),
 		)),
-		// provide the grpc exporter as a generic span exporter as well
+		// provide the otlp exporter as a generic span exporter as well
 		fx.Provide(func(e *otlptrace.Exporter) sdktrace.SpanExporter { return e }),
-		// detect expects ecs resource
-		fx.Provide(ecs.NewResourceDetector),
-		// decorate to fix an issue that prevents log correlation
-		fx.Decorate(WithExtraEcsAttributes),
+		// detect the resource with the configured detectors, named after the (optional) build info
+		fx.Provide(fx.Annotate(NewResourceDetector, fx.ParamTags(``, `optional:"true"`))),
 
-		// provide dependencies for metric export
-		fx.Provide(sdkmetric.NewPeriodicReader),
-		fx.Provide(NewMetricExporter),
+		// provide the reader that exports metrics to the collector, unless it is disabled
+		fx.Provide(fx.Annotate(newOTLPMetricReaders, fx.ResultTags(`group:"`+metricReadersGroup+`,flatten"`))),
+		// export the zap logs to the collector as well, if it is enabled
+		clzap.ProvideSecondaryCore(newLogCore),
 	)
 }
 
@@ -0,0 +1,104 @@
+package clotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// PrometheusHandlerName is the name of the http.Handler that serves the Prometheus metrics. It can be mounted into
+// a clwebserver server with: clwebserver.ProvideHandler(server, "/metrics", clotel.PrometheusHandlerName).
+const PrometheusHandlerName = moduleName + ".prometheus"
+
+// metricReadersGroup is the fx value group of additional metric readers.
+const metricReadersGroup = moduleName + ".metric_readers"
+
+// NewPrometheusRegistry inits the registry that is scraped, it includes the process metrics.
+func NewPrometheusRegistry() (*prometheus.Registry, error) {
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})); err != nil {
+		return nil, fmt.Errorf("failed to register process collector: %w", err)
+	}
+
+	return reg, nil
+}
+
+// NewPrometheusExporter inits a metric reader that exposes the metrics to the registry.
+func NewPrometheusExporter(reg *prometheus.Registry) (*otelprom.Exporter, error) {
+	exp, err := otelprom.New(otelprom.WithRegisterer(reg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to init prometheus exporter: %w", err)
+	}
+
+	return exp, nil
+}
+
+// NewPrometheusHandler inits the handler that serves the registry's metrics for scraping.
+func NewPrometheusHandler(logs *zap.Logger, reg *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{
+		ErrorLog: zap.NewStdLog(logs),
+		Registry: reg,
+	})
+}
+
+// servePrometheus serves the metrics on their own listener, if it is configured.
+func servePrometheus(cfg Config, logs *zap.Logger, lc fx.Lifecycle, h http.Handler) {
+	if cfg.PrometheusBindAddrPort == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(cfg.PrometheusPath, h)
+
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: time.Second * 5, ErrorLog: zap.NewStdLog(logs)}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			ln, err := (&net.ListenConfig{}).Listen(ctx, "tcp", cfg.PrometheusBindAddrPort)
+			if err != nil {
+				return fmt.Errorf("failed to listen: %w", err)
+			}
+
+			go func() {
+				if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logs.Error("failed to serve prometheus metrics", zap.Error(err))
+				}
+			}()
+
+			logs.Info("serving prometheus metrics", zap.Stringer("addr", ln.Addr()))
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if err := srv.Shutdown(ctx); err != nil {
+				return fmt.Errorf("failed to shut down: %w", err)
+			}
+
+			return nil
+		},
+	})
+}
+
+// PrometheusProvide registers a Prometheus exporter as an (additional) metric reader. The metrics are served on
+// their own listener if PROMETHEUS_BIND_ADDR_PORT is configured, and the handler is provided so it can be mounted
+// into a webserver. Use it with DISABLE_OTLP_METRICS when there is no collector.
+func PrometheusProvide() fx.Option {
+	return fx.Options(
+		fx.Provide(NewPrometheusRegistry, NewPrometheusExporter),
+		fx.Provide(fx.Annotate(func(e *otelprom.Exporter) sdkmetric.Reader { return e },
+			fx.ResultTags(`group:"`+metricReadersGroup+`"`))),
+		fx.Provide(fx.Annotate(NewPrometheusHandler, fx.ResultTags(`name:"`+PrometheusHandlerName+`"`))),
+		fx.Invoke(fx.Annotate(servePrometheus, fx.ParamTags(``, ``, ``, `name:"`+PrometheusHandlerName+`"`))),
+	)
+}
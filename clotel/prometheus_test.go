@@ -0,0 +1,63 @@
+package clotel_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/crewlinker/clgo/clotel"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/fx"
+)
+
+var _ = Describe("prometheus metrics", func() {
+	var mpi metric.MeterProvider
+	var hdl http.Handler
+	var addr string
+
+	BeforeEach(func(ctx context.Context) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		addr = ln.Addr().String()
+		Expect(ln.Close()).To(Succeed())
+
+		os.Setenv("CLOTEL_PROMETHEUS_BIND_ADDR_PORT", addr)
+		DeferCleanup(os.Unsetenv, "CLOTEL_PROMETHEUS_BIND_ADDR_PORT")
+
+		app := fx.New(
+			fx.Populate(&mpi, fx.Annotate(&hdl, fx.ParamTags(`name:"`+clotel.PrometheusHandlerName+`"`))),
+			clotel.TestProvide(), clotel.PrometheusProvide(), clzap.TestProvide())
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should serve metrics from the handler", func(ctx context.Context) {
+		ctr, err := mpi.Meter("some_test").Int64Counter("some.counter")
+		Expect(err).ToNot(HaveOccurred())
+		ctr.Add(ctx, 42)
+
+		rec := httptest.NewRecorder()
+		hdl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(ContainSubstring(`some_counter_total{otel_scope_name="some_test",otel_scope_version=""} 42`))
+		Expect(rec.Body.String()).To(ContainSubstring(`process_runtime_go_goroutines`))
+		Expect(rec.Body.String()).To(ContainSubstring(`process_cpu_seconds_total`))
+	})
+
+	It("should serve metrics on their own listener", func() {
+		resp, err := http.Get("http://" + addr + "/metrics")
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(string(body)).To(ContainSubstring(`target_info{service_name="ClTest"} 1`))
+	})
+})
@@ -0,0 +1,51 @@
+package clotel
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/crewlinker/clgo/clzap"
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewPropagator inits a composite of the configured propagators: tracecontext, baggage and xray. Context is
+// extracted from the headers of each propagator, in order, and injected into the headers of all of them.
+func NewPropagator(cfg Config) (propagation.TextMapPropagator, error) {
+	props := make([]propagation.TextMapPropagator, 0, len(cfg.Propagators))
+
+	for _, name := range cfg.Propagators {
+		switch name {
+		case "tracecontext":
+			props = append(props, propagation.TraceContext{})
+		case "baggage":
+			props = append(props, propagation.Baggage{})
+		case "xray":
+			props = append(props, xray.Propagator{})
+		default:
+			return nil, fmt.Errorf("unsupported propagator: '%s'", name) //nolint:goerr113
+		}
+	}
+
+	return propagation.NewCompositeTextMapPropagator(props...), nil
+}
+
+// NewIDGenerator inits the X-Ray id generator if the xray propagator is configured, since X-Ray requires the
+// trace ids to start with a timestamp. Otherwise it returns nil, and the tracer provider generates random ids.
+func NewIDGenerator(cfg Config) sdktrace.IDGenerator {
+	if !slices.Contains(cfg.Propagators, "xray") {
+		return nil
+	}
+
+	return xray.NewIDGenerator()
+}
+
+// traceIDFormat returns the format of the trace ids in the logs that matches the propagators.
+func traceIDFormat(cfg Config) clzap.TraceIDFormat {
+	if slices.Contains(cfg.Propagators, "xray") {
+		return clzap.TraceIDFormatXRay
+	}
+
+	return clzap.TraceIDFormatW3C
+}
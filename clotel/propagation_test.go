@@ -0,0 +1,72 @@
+package clotel_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/crewlinker/clgo/clotel"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+)
+
+var _ = Describe("propagation", func() {
+	It("should extract w3c and inject all headers", func(ctx context.Context) {
+		prop, err := clotel.NewPropagator(clotel.Config{Propagators: []string{"tracecontext", "baggage", "xray"}})
+		Expect(err).ToNot(HaveOccurred())
+
+		ctx = prop.Extract(ctx, propagation.HeaderCarrier(http.Header{
+			"Traceparent": {"00-5759e988bd862e3fe1be46a994272793-53995c3f42cd8ad8-01"},
+			"Baggage":     {"tenant=acme"},
+		}))
+
+		sc := trace.SpanContextFromContext(ctx)
+		Expect(sc.TraceID().String()).To(Equal("5759e988bd862e3fe1be46a994272793"))
+		Expect(baggage.FromContext(ctx).Member("tenant").Value()).To(Equal("acme"))
+
+		hdr := http.Header{}
+		prop.Inject(ctx, propagation.HeaderCarrier(hdr))
+		Expect(hdr.Get("Traceparent")).To(Equal("00-5759e988bd862e3fe1be46a994272793-53995c3f42cd8ad8-01"))
+		Expect(hdr.Get("Baggage")).To(Equal("tenant=acme"))
+		Expect(hdr.Get("X-Amzn-Trace-Id")).To(Equal(
+			"Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1"))
+	})
+
+	It("should not support unknown propagators", func() {
+		_, err := clotel.NewPropagator(clotel.Config{Propagators: []string{"b3"}})
+		Expect(err).To(MatchError(`unsupported propagator: 'b3'`))
+	})
+
+	It("should only generate xray ids with the xray propagator", func() {
+		Expect(clotel.NewIDGenerator(clotel.Config{Propagators: []string{"xray"}})).ToNot(BeNil())
+		Expect(clotel.NewIDGenerator(clotel.Config{Propagators: []string{"tracecontext"}})).To(BeNil())
+	})
+
+	Describe("without xray", func() {
+		var tpi trace.TracerProvider
+		BeforeEach(func(ctx context.Context) {
+			os.Setenv("CLOTEL_PROPAGATORS", "tracecontext,baggage")
+			DeferCleanup(os.Unsetenv, "CLOTEL_PROPAGATORS")
+			DeferCleanup(clzap.SetTraceIDFormat, clzap.TraceIDFormatXRay)
+
+			app := fx.New(fx.Populate(&tpi), clotel.TestProvide(), clzap.TestProvide())
+			Expect(app.Start(ctx)).To(Succeed())
+			DeferCleanup(app.Stop)
+		})
+
+		It("should install the propagator globally and log w3c trace ids", func(ctx context.Context) {
+			Expect(otel.GetTextMapPropagator().Fields()).To(ConsistOf("traceparent", "tracestate", "baggage"))
+
+			_, span := tpi.Tracer("test").Start(ctx, "my-span")
+			defer span.End()
+
+			Expect(clzap.FormatTraceID(span.SpanContext().TraceID())).To(Equal(span.SpanContext().TraceID().String()))
+		})
+	})
+})
@@ -0,0 +1,116 @@
+package clotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/crewlinker/clgo/clbuildinfo"
+	"go.opentelemetry.io/contrib/detectors/aws/ecs"
+	"go.opentelemetry.io/contrib/detectors/aws/lambda"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// detectorFunc implements the resource.Detector as a function.
+type detectorFunc func(ctx context.Context) (*resource.Resource, error)
+
+func (f detectorFunc) Detect(ctx context.Context) (*resource.Resource, error) { return f(ctx) }
+
+// sdkDetector detects a resource with the sdk's options, since the sdk doesn't export its detectors.
+func sdkDetector(opt resource.Option) resource.Detector {
+	return detectorFunc(func(ctx context.Context) (*resource.Resource, error) {
+		return resource.New(ctx, opt) //nolint:wrapcheck
+	})
+}
+
+// detectors that can be configured by name.
+var detectors = map[string]func() resource.Detector{
+	"ecs": func() resource.Detector { return WithExtraEcsAttributes(ecs.NewResourceDetector()) },
+	"lambda": func() resource.Detector {
+		// the lambda detector fails outside of a lambda, we want to be able to configure it regardless
+		return detectorFunc(func(ctx context.Context) (*resource.Resource, error) {
+			if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") == "" {
+				return resource.Empty(), nil
+			}
+
+			return lambda.NewResourceDetector().Detect(ctx) //nolint:wrapcheck
+		})
+	},
+	"ec2":     func() resource.Detector { return NewEC2Detector(imds.New(imds.Options{})) },
+	"env":     func() resource.Detector { return sdkDetector(resource.WithFromEnv()) },
+	"host":    func() resource.Detector { return sdkDetector(resource.WithHost()) },
+	"process": func() resource.Detector { return sdkDetector(resource.WithProcess()) },
+}
+
+// NewResourceDetector inits a detector that merges the resources of the configured detectors, later detectors take
+// precedence. The service name and version of the build info, if provided, are used unless a detector overwrites
+// them.
+func NewResourceDetector(cfg Config, info clbuildinfo.Info) (resource.Detector, error) {
+	dets := make([]resource.Detector, 0, len(cfg.ResourceDetectors))
+
+	for _, name := range cfg.ResourceDetectors {
+		newDet, ok := detectors[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported resource detector: '%s'", name) //nolint:goerr113
+		}
+
+		dets = append(dets, newDet())
+	}
+
+	return detectorFunc(func(ctx context.Context) (*resource.Resource, error) {
+		var kvs []attribute.KeyValue
+		if info.Name() != "" {
+			kvs = append(kvs, semconv.ServiceNameKey.String(info.Name()))
+		}
+
+		if info.Version() != "" {
+			kvs = append(kvs, semconv.ServiceVersionKey.String(info.Version()))
+		}
+
+		res := resource.NewSchemaless(kvs...)
+
+		for _, det := range dets {
+			dres, err := det.Detect(ctx)
+			if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+				return nil, fmt.Errorf("failed to detect: %w", err)
+			}
+
+			if dres == nil {
+				continue
+			}
+
+			// detectors use different semconv versions, so their schema urls would conflict when merged
+			if res, err = resource.Merge(res, resource.NewSchemaless(dres.Attributes()...)); err != nil {
+				return nil, fmt.Errorf("failed to merge: %w", err)
+			}
+		}
+
+		return res, nil
+	}), nil
+}
+
+// NewEC2Detector inits a detector for the EC2 instance, using the instance metadata service. Outside of EC2 it
+// detects an empty resource.
+func NewEC2Detector(client *imds.Client) resource.Detector {
+	return detectorFunc(func(ctx context.Context) (*resource.Resource, error) {
+		out, err := client.GetInstanceIdentityDocument(ctx, &imds.GetInstanceIdentityDocumentInput{})
+		if err != nil {
+			return resource.Empty(), nil //nolint:nilerr
+		}
+
+		return resource.NewSchemaless(
+			semconv.CloudProviderAWS,
+			semconv.CloudPlatformAWSEC2,
+			semconv.CloudRegionKey.String(out.Region),
+			semconv.CloudAvailabilityZoneKey.String(out.AvailabilityZone),
+			semconv.CloudAccountIDKey.String(out.AccountID),
+			semconv.HostIDKey.String(out.InstanceID),
+			semconv.HostImageIDKey.String(out.ImageID),
+			semconv.HostTypeKey.String(out.InstanceType),
+		), nil
+	})
+}
@@ -0,0 +1,94 @@
+package clotel_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/crewlinker/clgo/clbuildinfo"
+	"github.com/crewlinker/clgo/clotel"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var _ = Describe("resource detection", func() {
+	attrs := func(res *resource.Resource) map[attribute.Key]string {
+		m := map[attribute.Key]string{}
+		for _, kv := range res.Attributes() {
+			m[kv.Key] = kv.Value.Emit()
+		}
+
+		return m
+	}
+
+	BeforeEach(func() {
+		for k, v := range map[string]string{
+			"AWS_LAMBDA_FUNCTION_NAME": "my-func",
+			"OTEL_RESOURCE_ATTRIBUTES": "deployment.environment=test",
+		} {
+			os.Setenv(k, v)
+			DeferCleanup(os.Unsetenv, k)
+		}
+	})
+
+	It("should merge the configured detectors with the build info", func(ctx context.Context) {
+		det, err := clotel.NewResourceDetector(clotel.Config{ResourceDetectors: []string{"ecs", "lambda", "env"}},
+			clbuildinfo.New(clbuildinfo.Config{Name: "my-svc"}, "v1.2.3"))
+		Expect(err).ToNot(HaveOccurred())
+
+		res, err := det.Detect(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(attrs(res)).To(And(
+			HaveKeyWithValue(attribute.Key("service.name"), "my-svc"),
+			HaveKeyWithValue(attribute.Key("service.version"), "v1.2.3"),
+			HaveKeyWithValue(attribute.Key("faas.name"), "my-func"),
+			HaveKeyWithValue(attribute.Key("deployment.environment"), "test"),
+			Not(HaveKey(attribute.Key("cloud.platform"))),
+		))
+	})
+
+	It("should let the environment overwrite the service name", func(ctx context.Context) {
+		os.Setenv("OTEL_SERVICE_NAME", "other-svc")
+		DeferCleanup(os.Unsetenv, "OTEL_SERVICE_NAME")
+
+		det, err := clotel.NewResourceDetector(clotel.Config{ResourceDetectors: []string{"env"}},
+			clbuildinfo.New(clbuildinfo.Config{Name: "my-svc"}, "v1.2.3"))
+		Expect(err).ToNot(HaveOccurred())
+
+		res, err := det.Detect(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(attrs(res)).To(HaveKeyWithValue(attribute.Key("service.name"), "other-svc"))
+	})
+
+	It("should not support unknown detectors", func() {
+		_, err := clotel.NewResourceDetector(clotel.Config{ResourceDetectors: []string{"gcp"}}, clbuildinfo.Info{})
+		Expect(err).To(MatchError(`unsupported resource detector: 'gcp'`))
+	})
+
+	It("should detect ec2 from the instance metadata", func(ctx context.Context) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/latest/api/token":
+				fmt.Fprint(w, "token")
+			case "/latest/dynamic/instance-identity/document":
+				fmt.Fprint(w, `{"region":"eu-west-1","instanceId":"i-123","instanceType":"t3.micro"}`)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		DeferCleanup(srv.Close)
+
+		res, err := clotel.NewEC2Detector(imds.New(imds.Options{Endpoint: srv.URL})).Detect(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(attrs(res)).To(And(
+			HaveKeyWithValue(attribute.Key("cloud.platform"), "aws_ec2"),
+			HaveKeyWithValue(attribute.Key("cloud.region"), "eu-west-1"),
+			HaveKeyWithValue(attribute.Key("host.id"), "i-123"),
+		))
+	})
+})
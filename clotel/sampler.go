@@ -0,0 +1,66 @@
+package clotel
+
+import (
+	"fmt"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+// NewSampler inits the sampler that is configured.
+func NewSampler(cfg Config) (sdktrace.Sampler, error) {
+	name, parentBased := strings.CutPrefix(cfg.TracesSampler, "parentbased_")
+
+	var smp sdktrace.Sampler
+
+	switch name {
+	case "always_on":
+		smp = sdktrace.AlwaysSample()
+	case "always_off":
+		smp = sdktrace.NeverSample()
+	case "traceidratio":
+		smp = sdktrace.TraceIDRatioBased(cfg.TracesSamplerRatio)
+	case "ratelimiting":
+		smp = NewRateLimitingSampler(cfg.TracesSamplerRate)
+	default:
+		return nil, fmt.Errorf("unsupported sampler: '%s'", cfg.TracesSampler) //nolint:goerr113
+	}
+
+	if parentBased {
+		smp = sdktrace.ParentBased(smp)
+	}
+
+	return smp, nil
+}
+
+// rateLimitingSampler samples at most a number of traces per second.
+type rateLimitingSampler struct {
+	lim  *rate.Limiter
+	desc string
+}
+
+// NewRateLimitingSampler inits a sampler that samples at most perSecond traces per second. It allows bursts of up
+// to a second's worth of traces.
+func NewRateLimitingSampler(perSecond float64) sdktrace.Sampler {
+	return &rateLimitingSampler{
+		lim:  rate.NewLimiter(rate.Limit(perSecond), max(1, int(perSecond))),
+		desc: fmt.Sprintf("RateLimitingSampler{%g}", perSecond),
+	}
+}
+
+func (s *rateLimitingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	res := sdktrace.SamplingResult{
+		Decision:   sdktrace.Drop,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+
+	if s.lim.Allow() {
+		res.Decision = sdktrace.RecordAndSample
+	}
+
+	return res
+}
+
+func (s *rateLimitingSampler) Description() string { return s.desc }
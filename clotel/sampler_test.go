@@ -0,0 +1,47 @@
+package clotel_test
+
+import (
+	"context"
+
+	"github.com/crewlinker/clgo/clotel"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("sampler", func() {
+	DescribeTable("configure", func(name string, expDesc string) {
+		smp, err := clotel.NewSampler(clotel.Config{TracesSampler: name, TracesSamplerRatio: 0.5, TracesSamplerRate: 10})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(smp.Description()).To(Equal(expDesc))
+	},
+		Entry("always on", "always_on", "AlwaysOnSampler"),
+		Entry("always off", "always_off", "AlwaysOffSampler"),
+		Entry("ratio", "traceidratio", "TraceIDRatioBased{0.5}"),
+		Entry("rate limiting", "ratelimiting", "RateLimitingSampler{10}"),
+		Entry("parent based ratio", "parentbased_traceidratio",
+			"ParentBased{root:TraceIDRatioBased{0.5},remoteParentSampled:AlwaysOnSampler,"+
+				"remoteParentNotSampled:AlwaysOffSampler,localParentSampled:AlwaysOnSampler,"+
+				"localParentNotSampled:AlwaysOffSampler}"),
+	)
+
+	It("should not support unknown samplers", func() {
+		_, err := clotel.NewSampler(clotel.Config{TracesSampler: "foo"})
+		Expect(err).To(MatchError(`unsupported sampler: 'foo'`))
+	})
+
+	It("should limit the rate", func(ctx context.Context) {
+		smp := clotel.NewRateLimitingSampler(2)
+
+		var sampled int
+		for range 10 {
+			if smp.ShouldSample(sdktrace.SamplingParameters{ParentContext: ctx, TraceID: trace.TraceID{1}}).
+				Decision == sdktrace.RecordAndSample {
+				sampled++
+			}
+		}
+
+		Expect(sampled).To(Equal(2))
+	})
+})
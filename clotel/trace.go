@@ -5,12 +5,11 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/crewlinker/clgo/clzap"
 	"github.com/go-logr/zapr"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -26,6 +25,7 @@ func NewTracerProvider(
 	det resource.Detector,
 	idg sdktrace.IDGenerator,
 	txtp propagation.TextMapPropagator,
+	smp sdktrace.Sampler,
 ) (*sdktrace.TracerProvider, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.DetectorDetectTimeout)
 	defer cancel()
@@ -45,14 +45,16 @@ func NewTracerProvider(
 
 	// for sdk logging we also need to set a global value
 	otel.SetLogger(zapr.NewLogger(logs))
-	// set the global text map propagator
+	// set the global text map propagator, and format the trace ids in the logs to match it
 	otel.SetTextMapPropagator(txtp)
+	clzap.SetTraceIDFormat(traceIDFormat(cfg))
 
 	// finally, init the actual provider
 	trp := sdktrace.NewTracerProvider(
 		sdktrace.WithResource(res),
 		sdktrace.WithBatcher(exp),
 		sdktrace.WithIDGenerator(idg),
+		sdktrace.WithSampler(smp),
 	)
 
 	// set it globally, but code should prefer to inject it during construction
@@ -67,15 +69,6 @@ func NewTracerProvider(
 	return trp, nil
 }
 
-// newGrcpExporter rturns the grpc exporter.
-func newGrpcExporter(cfg Config) *otlptrace.Exporter {
-	return otlptracegrpc.NewUnstarted(
-		otlptracegrpc.WithInsecure(),
-		otlptracegrpc.WithTimeout(cfg.ExporterTimeout),
-		otlptracegrpc.WithEndpoint(cfg.ExporterEndpoint),
-	)
-}
-
 // we need a custom detector because of the log correlation issue described here:
 // https://github.com/aws-observability/aws-otel-collector/issues/1766
 type extraEcsDetector struct{ resource.Detector }
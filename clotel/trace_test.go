@@ -77,6 +77,6 @@ var _ = Describe("extra ecs detector", func() {
 	It("should overwrite cloudwatch log arns/names", func(ctx context.Context) {
 		res, err := det.Detect(ctx)
 		Expect(err).ToNot(HaveOccurred())
-		Expect(fmt.Sprint(res)).To(Equal(`aws.log.group.arns=[xyz],aws.log.group.names=[zyx]`))
+		Expect(fmt.Sprint(res)).To(Equal(`aws.log.group.arns=["xyz"],aws.log.group.names=["zyx"]`))
 	})
 })
@@ -0,0 +1,127 @@
+package clpgxmigrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crewlinker/clgo/clid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CLIDTypeSQL creates the composite type that stores a clid natively, and functions to convert between the
+// text representation of the ulid and the uuid it is stored as. The conversion functions are mostly useful for
+// migrating existing (varchar) columns and for debugging.
+var CLIDTypeSQL = []string{
+	fmt.Sprintf(`CREATE TYPE %s AS (prefix char(%d), ulid uuid)`,
+		pgx.Identifier{clid.PostgresTypeName}.Sanitize(), clid.PrefixSize),
+	`CREATE FUNCTION clid_ulid_to_uuid(s text) RETURNS uuid AS $$
+DECLARE
+	alphabet CONSTANT text := '0123456789ABCDEFGHJKMNPQRSTVWXYZ';
+	n numeric := 0;
+	d int;
+	h text := '';
+BEGIN
+	IF length(s) <> 26 THEN
+		RAISE EXCEPTION 'clid: invalid ulid length: %', s;
+	END IF;
+	FOR i IN 1..26 LOOP
+		d := position(substr(upper(s), i, 1) IN alphabet) - 1;
+		IF d < 0 THEN
+			RAISE EXCEPTION 'clid: invalid ulid character: %', s;
+		END IF;
+		n := n * 32 + d;
+	END LOOP;
+	FOR i IN 1..32 LOOP
+		h := substr('0123456789abcdef', mod(n, 16)::int + 1, 1) || h;
+		n := div(n, 16);
+	END LOOP;
+	IF n <> 0 THEN
+		RAISE EXCEPTION 'clid: ulid overflows 128 bits: %', s;
+	END IF;
+	RETURN h::uuid;
+END $$ LANGUAGE plpgsql IMMUTABLE STRICT`,
+	`CREATE FUNCTION clid_uuid_to_ulid(u uuid) RETURNS text AS $$
+DECLARE
+	alphabet CONSTANT text := '0123456789ABCDEFGHJKMNPQRSTVWXYZ';
+	h text := replace(u::text, '-', '');
+	n numeric := 0;
+	s text := '';
+BEGIN
+	FOR i IN 1..32 LOOP
+		n := n * 16 + position(substr(h, i, 1) IN '0123456789abcdef') - 1;
+	END LOOP;
+	FOR i IN 1..26 LOOP
+		s := substr(alphabet, mod(n, 32)::int + 1, 1) || s;
+		n := div(n, 32);
+	END LOOP;
+	RETURN s;
+END $$ LANGUAGE plpgsql IMMUTABLE STRICT`,
+	fmt.Sprintf(`CREATE FUNCTION clid_text(id %[1]s) RETURNS text AS $$
+	SELECT (id).prefix || '%[2]s' || clid_uuid_to_ulid((id).ulid)
+$$ LANGUAGE sql IMMUTABLE STRICT`, pgx.Identifier{clid.PostgresTypeName}.Sanitize(), clid.Separator),
+}
+
+// NewCLIDTypeStep returns a step that creates the native clid type and its conversion functions.
+func NewCLIDTypeStep() Step {
+	return NewStep(func(ctx context.Context, tx pgx.Tx) error {
+		for _, stmt := range CLIDTypeSQL {
+			if _, err := tx.Exec(ctx, stmt); err != nil {
+				return fmt.Errorf("failed to create clid type (%s): %w", stmt, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// CLIDColumn describes a varchar column holding clids that is converted to the native clid type.
+type CLIDColumn struct {
+	// Table of the column, may be schema qualified.
+	Table string
+	// Column to convert.
+	Column string
+	// Prefix that all ids in the column must have, it is enforced with a CHECK constraint.
+	Prefix string
+}
+
+// SQL returns the statements that convert the column to the native type and add the prefix check. Foreign keys
+// that reference the column need to be dropped before, and re-created after both sides are converted.
+func (c CLIDColumn) SQL() []string {
+	parts := strings.SplitN(c.Table, ".", 2) //nolint:gomnd
+	table, col := pgx.Identifier(parts).Sanitize(), pgx.Identifier{c.Column}.Sanitize()
+	check := pgx.Identifier{parts[len(parts)-1] + "_" + c.Column + "_clid_prefix"}.Sanitize()
+
+	return []string{
+		fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN %s TYPE %s USING `+
+			`(split_part(%s, '%s', 1), clid_ulid_to_uuid(split_part(%s, '%s', 2)))::%s`,
+			table, col, pgx.Identifier{clid.PostgresTypeName}.Sanitize(),
+			col, clid.Separator, col, clid.Separator, pgx.Identifier{clid.PostgresTypeName}.Sanitize()),
+		fmt.Sprintf(`ALTER TABLE %s ADD CONSTRAINT %s CHECK ((%s).prefix = '%s')`,
+			table, check, col, strings.ReplaceAll(c.Prefix, "'", "''")),
+	}
+}
+
+// Apply the conversion in the transaction.
+func (c CLIDColumn) Apply(ctx context.Context, tx pgx.Tx) error {
+	for _, stmt := range c.SQL() {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to convert clid column (%s): %w", stmt, err)
+		}
+	}
+
+	return nil
+}
+
+// NewCLIDColumnStep returns a step that converts each of the columns to the native clid type.
+func NewCLIDColumnStep(cols ...CLIDColumn) Step {
+	return NewStep(func(ctx context.Context, tx pgx.Tx) error {
+		for _, c := range cols {
+			if err := c.Apply(ctx, tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package clpgxmigrate_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/crewlinker/clgo/clid"
+	"github.com/crewlinker/clgo/clpostgres/clpgxmigrate"
+	. "github.com/onsi/gomega"
+)
+
+func TestCLIDColumn(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sql", func(t *testing.T) {
+		t.Parallel()
+		_, g := Setup(t)
+
+		g.Expect(clpgxmigrate.CLIDColumn{Table: "public.users", Column: "id", Prefix: "user"}.SQL()).To(Equal([]string{
+			`ALTER TABLE "public"."users" ALTER COLUMN "id" TYPE "clid" USING ` +
+				`(split_part("id", '-', 1), clid_ulid_to_uuid(split_part("id", '-', 2)))::"clid"`,
+			`ALTER TABLE "public"."users" ADD CONSTRAINT "users_id_clid_prefix" CHECK (("id").prefix = 'user')`,
+		}))
+	})
+
+	t.Run("convert varchar column", func(t *testing.T) {
+		t.Parallel()
+		ctx, g, conn := SetupConn(t)
+
+		_, err := conn.Exec(ctx, `CREATE TABLE users (id varchar(31) PRIMARY KEY)`)
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = conn.Exec(ctx, `INSERT INTO users VALUES ('user-01HGWKKAWGABYZR1S1G9JMY5HZ')`)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		coll := clpgxmigrate.NewCollection()
+		g.Expect(coll.Register("001_clid", clpgxmigrate.NewCLIDTypeStep())).To(Succeed())
+		g.Expect(coll.Register("002_users", clpgxmigrate.NewCLIDColumnStep(
+			clpgxmigrate.CLIDColumn{Table: "users", Column: "id", Prefix: "user"}))).To(Succeed())
+
+		_, err = clpgxmigrate.NewProvider(conn, clpgxmigrate.WithCollection(coll)).Migrate(ctx, math.MaxInt64)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		var text string
+		g.Expect(conn.QueryRow(ctx, `SELECT clid_text(id) FROM users`).Scan(&text)).To(Succeed())
+		g.Expect(text).To(Equal("user-01HGWKKAWGABYZR1S1G9JMY5HZ"))
+
+		g.Expect(clid.RegisterPgxType(ctx, conn)).To(Succeed())
+
+		var id clid.ID
+		g.Expect(conn.QueryRow(ctx, `SELECT id FROM users`).Scan(&id)).To(Succeed())
+		g.Expect(id.String()).To(Equal("user-01HGWKKAWGABYZR1S1G9JMY5HZ"))
+
+		_, err = conn.Exec(ctx, `INSERT INTO users VALUES ($1)`, clid.New("user"))
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = conn.Exec(ctx, `INSERT INTO users VALUES ($1)`, clid.New("orga"))
+		g.Expect(err).To(MatchError(ContainSubstring(`users_id_clid_prefix`)))
+	})
+}
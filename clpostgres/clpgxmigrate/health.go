@@ -0,0 +1,68 @@
+package clpgxmigrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/crewlinker/clgo/clconfig"
+	"github.com/crewlinker/clgo/clhealth"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/fx"
+)
+
+// ErrNotAtExpectedVersion is returned by the health check when the schema is not migrated to the expected version.
+var ErrNotAtExpectedVersion = errors.New("clpgxmigrate: schema is not at the expected version")
+
+// Config configures the package.
+type Config struct {
+	// ExpectedVersion is the schema version that the health check expects, the latest version of the collection is
+	// expected if it is negative.
+	ExpectedVersion int64 `env:"EXPECTED_VERSION" envDefault:"-1"`
+}
+
+// NewChecker inits a health check that fails when the schema is not migrated to the expected version. If the
+// expected version is negative, the latest version of the collection is expected.
+func NewChecker(pool *pgxpool.Pool, expected int64, os ...Option) clhealth.Checker {
+	return clhealth.Checker{Name: "migrations", Check: func(ctx context.Context) error {
+		conn, err := pool.Acquire(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to acquire connection: %w", err)
+		}
+
+		defer conn.Release()
+
+		status, err := NewProvider(conn.Conn(), os...).Status(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get status: %w", err)
+		}
+
+		want := expected
+		if want < 0 {
+			want = status.LatestVersion
+		}
+
+		if status.CurrentVersion != want {
+			return fmt.Errorf("%w: %d, expected: %d", ErrNotAtExpectedVersion, status.CurrentVersion, want)
+		}
+
+		return nil
+	}}
+}
+
+// moduleName for consistent config parsing.
+const moduleName = "clpgxmigrate"
+
+// Provide registers the health check of the migrations on the read-write pool, with the expected version from the
+// environment. The options configure the provider that reads the version, as with NewProvider.
+func Provide(os ...Option) fx.Option {
+	return fx.Module(moduleName,
+		// provide the environment configuration
+		clconfig.Provide[Config](strings.ToUpper(moduleName)+"_"),
+		// register the health check
+		clhealth.ProvideChecker(func(cfg Config, pool *pgxpool.Pool) clhealth.Checker {
+			return NewChecker(pool, cfg.ExpectedVersion, os...)
+		}, ``, `name:"rw"`),
+	)
+}
@@ -0,0 +1,63 @@
+package clpgxmigrate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/caarlos0/env/v10"
+	"github.com/crewlinker/clgo/clhealth"
+	"github.com/crewlinker/clgo/clpostgres/clpgxmigrate"
+	"github.com/jackc/pgx/v5/pgxpool"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+func TestHealth(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		environ  map[string]string
+		expectOK bool
+	}{
+		{name: "latest version", environ: map[string]string{}, expectOK: false},
+		{name: "configured version", environ: map[string]string{"CLPGXMIGRATE_EXPECTED_VERSION": "0"}, expectOK: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, g, conn := SetupConn(t)
+
+			pool, err := pgxpool.New(ctx, conn.Config().ConnString())
+			g.Expect(err).ToNot(HaveOccurred())
+			t.Cleanup(pool.Close)
+
+			var hlth *clhealth.Health
+			app := fx.New(fx.Populate(&hlth),
+				fx.Supply(env.Options{Environment: tc.environ}, zap.NewNop()),
+				fx.Supply(fx.Annotate(pool, fx.ResultTags(`name:"rw"`))),
+				fx.Decorate(func(c clhealth.Config) clhealth.Config {
+					c.CacheTTL = 0
+
+					return c
+				}),
+				clhealth.Provide(),
+				clpgxmigrate.Provide())
+			g.Expect(app.Start(ctx)).To(Succeed())
+			t.Cleanup(func() { g.Expect(app.Stop(context.Background())).To(Succeed()) })
+
+			g.Expect(hlth.Ready(ctx).Checks).To(HaveKeyWithValue("migrations",
+				HaveField("Status", clhealth.StatusFail))) // not migrated at all
+
+			_, err = clpgxmigrate.New(conn).Migrate(ctx, 0)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			status := clhealth.StatusFail
+			if tc.expectOK {
+				status = clhealth.StatusOK
+			}
+
+			g.Expect(hlth.Ready(ctx).Checks).To(HaveKeyWithValue("migrations", HaveField("Status", status)))
+		})
+	}
+}
@@ -149,6 +149,7 @@ func (p *Provider) Migrate(ctx context.Context, targetVersion int64) (*Result, e
 
 type Status struct {
 	CurrentVersion int64
+	LatestVersion  int64
 }
 
 func (p *Provider) Status(ctx context.Context) (status *Status, err error) {
@@ -158,5 +159,9 @@ func (p *Provider) Status(ctx context.Context) (status *Status, err error) {
 		return nil, fmt.Errorf("failed to determine current version: %w", err)
 	}
 
+	if versions := p.coll.Versions(); len(versions) > 0 {
+		status.LatestVersion = slices.Max(versions)
+	}
+
 	return status, nil
 }
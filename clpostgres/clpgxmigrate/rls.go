@@ -0,0 +1,72 @@
+package clpgxmigrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TenantPolicy describes a standard row-level security policy that isolates rows per tenant.
+type TenantPolicy struct {
+	// Table that the policy is created on, may be schema qualified.
+	Table string
+	// Column that holds the tenant id, defaults to "tenant_id".
+	Column string
+	// Setting that holds the tenant id of the current transaction, defaults to "app.tenant_id".
+	Setting string
+	// Role the policy applies to, defaults to PUBLIC.
+	Role string
+}
+
+// SQL returns the statements that enable (and force) row-level security on the table and create the
+// policy. The setting is read with missing_ok so a transaction without a tenant matches no rows.
+func (p TenantPolicy) SQL() []string {
+	if p.Column == "" {
+		p.Column = "tenant_id"
+	}
+
+	if p.Setting == "" {
+		p.Setting = "app.tenant_id"
+	}
+
+	table, role := pgx.Identifier(strings.SplitN(p.Table, ".", 2)).Sanitize(), "PUBLIC"
+	if p.Role != "" {
+		role = pgx.Identifier{p.Role}.Sanitize()
+	}
+
+	check := fmt.Sprintf(`%s = current_setting('%s', true)`,
+		pgx.Identifier{p.Column}.Sanitize(), strings.ReplaceAll(p.Setting, "'", "''"))
+
+	return []string{
+		fmt.Sprintf(`ALTER TABLE %s ENABLE ROW LEVEL SECURITY`, table),
+		fmt.Sprintf(`ALTER TABLE %s FORCE ROW LEVEL SECURITY`, table),
+		fmt.Sprintf(`CREATE POLICY tenant_isolation ON %s TO %s USING (%s) WITH CHECK (%s)`,
+			table, role, check, check),
+	}
+}
+
+// Apply the policy in the transaction.
+func (p TenantPolicy) Apply(ctx context.Context, tx pgx.Tx) error {
+	for _, stmt := range p.SQL() {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to apply tenant policy (%s): %w", stmt, err)
+		}
+	}
+
+	return nil
+}
+
+// NewTenantPolicyStep returns a step that creates tenant isolation policies for each of the tables.
+func NewTenantPolicyStep(policies ...TenantPolicy) Step {
+	return NewStep(func(ctx context.Context, tx pgx.Tx) error {
+		for _, p := range policies {
+			if err := p.Apply(ctx, tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
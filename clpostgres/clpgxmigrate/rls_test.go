@@ -0,0 +1,100 @@
+package clpgxmigrate_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/crewlinker/clgo/clpostgres/clpgxmigrate"
+	"github.com/jackc/pgx/v5"
+	. "github.com/onsi/gomega"
+)
+
+func TestTenantPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sql", func(t *testing.T) {
+		t.Parallel()
+		_, g := Setup(t)
+
+		g.Expect(clpgxmigrate.TenantPolicy{Table: "public.orders", Role: "app"}.SQL()).To(Equal([]string{
+			`ALTER TABLE "public"."orders" ENABLE ROW LEVEL SECURITY`,
+			`ALTER TABLE "public"."orders" FORCE ROW LEVEL SECURITY`,
+			`CREATE POLICY tenant_isolation ON "public"."orders" TO "app" ` +
+				`USING ("tenant_id" = current_setting('app.tenant_id', true)) ` +
+				`WITH CHECK ("tenant_id" = current_setting('app.tenant_id', true))`,
+		}))
+	})
+
+	t.Run("isolate tenants", func(t *testing.T) {
+		t.Parallel()
+		ctx, g, conn := SetupConn(t)
+
+		_, err := conn.Exec(ctx, `CREATE TABLE orders (id int, tenant_id text)`)
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = conn.Exec(ctx, `INSERT INTO orders VALUES (1, 'a'), (2, 'b')`)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		coll := clpgxmigrate.NewCollection()
+		g.Expect(coll.Register("001_rls", clpgxmigrate.NewTenantPolicyStep(
+			clpgxmigrate.TenantPolicy{Table: "orders"}))).To(Succeed())
+
+		_, err = clpgxmigrate.NewProvider(conn, clpgxmigrate.WithCollection(coll)).Migrate(ctx, math.MaxInt64)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// superusers and table owners bypass the policies, so the assertions run as an unprivileged role
+		role := fmt.Sprintf("tenant_%d", time.Now().UnixNano())
+		_, err = conn.Exec(ctx, `CREATE ROLE `+role+` NOSUPERUSER NOBYPASSRLS`)
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = conn.Exec(ctx, `GRANT SELECT, INSERT ON orders TO `+role)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		t.Cleanup(func() {
+			_, err := conn.Exec(ctx, `DROP OWNED BY `+role)
+			g.Expect(err).ToNot(HaveOccurred())
+			_, err = conn.Exec(ctx, `DROP ROLE `+role)
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+
+		scoped := func(tenant string, fn func(tx pgx.Tx) error) error {
+			tx, err := conn.Begin(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+			defer tx.Rollback(ctx)
+
+			_, err = tx.Exec(ctx, `SET LOCAL ROLE `+role)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			if tenant != "" {
+				_, err = tx.Exec(ctx, `SELECT set_config('app.tenant_id', $1, true)`, tenant)
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			return fn(tx)
+		}
+
+		count := func(tenant string) (n int) {
+			g.Expect(scoped(tenant, func(tx pgx.Tx) error {
+				return tx.QueryRow(ctx, `SELECT count(*) FROM orders`).Scan(&n)
+			})).To(Succeed())
+
+			return n
+		}
+
+		g.Expect(count("a")).To(Equal(1))
+		g.Expect(count("b")).To(Equal(1))
+		g.Expect(count("")).To(Equal(0))
+
+		g.Expect(scoped("a", func(tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, `INSERT INTO orders VALUES (3, 'a')`)
+
+			return err
+		})).To(Succeed())
+
+		g.Expect(scoped("a", func(tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, `INSERT INTO orders VALUES (3, 'c')`)
+
+			return err
+		})).To(MatchError(MatchRegexp(`row-level security`)))
+	})
+}
@@ -0,0 +1,109 @@
+package cltx
+
+import (
+	"context"
+	"sync"
+)
+
+// Hooks holds callbacks that are run when the transaction in the context is committed or
+// rolled back. It is safe for concurrent use.
+type Hooks struct {
+	mu            sync.Mutex
+	afterCommit   []func(ctx context.Context)
+	afterRollback []func(ctx context.Context)
+}
+
+// WithHooks returns a context with a new set of hooks. Code that begins a transaction should call this
+// and run the hooks with RunAfterCommit or RunAfterRollback when the tx is done.
+func WithHooks(ctx context.Context) (context.Context, *Hooks) {
+	hks := &Hooks{}
+
+	return context.WithValue(ctx, ctxKey("hooks"), hks), hks
+}
+
+// AfterCommit registers fn to be called after the transaction in the context is committed. If the
+// context has no hooks the function panics.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	hooks(ctx).add(fn, nil)
+}
+
+// AfterRollback registers fn to be called after the transaction in the context is rolled back. If the
+// context has no hooks the function panics.
+func AfterRollback(ctx context.Context, fn func(ctx context.Context)) {
+	hooks(ctx).add(nil, fn)
+}
+
+// RunAfterCommit runs the after-commit callbacks in the order they were registered.
+func (h *Hooks) RunAfterCommit(ctx context.Context) {
+	for _, fn := range h.take(true) {
+		fn(ctx)
+	}
+}
+
+// RunAfterRollback runs the after-rollback callbacks in the order they were registered.
+func (h *Hooks) RunAfterRollback(ctx context.Context) {
+	for _, fn := range h.take(false) {
+		fn(ctx)
+	}
+}
+
+// merge moves the callbacks into the parent hooks, used when a savepoint is released and its
+// callbacks now depend on the outcome of the outer transaction.
+func (h *Hooks) merge(parent *Hooks) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	parent.mu.Lock()
+	defer parent.mu.Unlock()
+
+	parent.afterCommit = append(parent.afterCommit, h.afterCommit...)
+	parent.afterRollback = append(parent.afterRollback, h.afterRollback...)
+	h.afterCommit, h.afterRollback = nil, nil
+}
+
+// pending returns whether any callbacks are registered.
+func (h *Hooks) pending() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.afterCommit) > 0 || len(h.afterRollback) > 0
+}
+
+// add callbacks.
+func (h *Hooks) add(afterCommit, afterRollback func(ctx context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if afterCommit != nil {
+		h.afterCommit = append(h.afterCommit, afterCommit)
+	}
+
+	if afterRollback != nil {
+		h.afterRollback = append(h.afterRollback, afterRollback)
+	}
+}
+
+// take the callbacks that should run, and clear all of them so they only run once.
+func (h *Hooks) take(committed bool) (fns []func(ctx context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fns = h.afterRollback
+	if committed {
+		fns = h.afterCommit
+	}
+
+	h.afterCommit, h.afterRollback = nil, nil
+
+	return fns
+}
+
+// hooks returns the hooks from the context or panics.
+func hooks(ctx context.Context) *Hooks {
+	hks, ok := ctx.Value(ctxKey("hooks")).(*Hooks)
+	if !ok {
+		panic("cltx: no tx hooks in context")
+	}
+
+	return hks
+}
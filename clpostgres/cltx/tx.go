@@ -3,6 +3,8 @@ package cltx
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
@@ -11,12 +13,20 @@ import (
 // scope contetvalues.
 type ctxKey string
 
+// ErrNoTx is returned when there is no transaction in the context.
+var ErrNoTx = errors.New("cltx: no tx in context")
+
 // Pgx returns the request scoped transaction initialized in the RWTransacter or
 // ROTransacter middleware. If this was not done the function will panic.
 func Pgx(ctx context.Context) pgx.Tx {
 	return Tx[pgx.Tx](ctx)
 }
 
+// TryPgx returns the pgx transaction from the context or an error if there is none.
+func TryPgx(ctx context.Context) (pgx.Tx, error) {
+	return TryTx[pgx.Tx](ctx)
+}
+
 // WithPgx returns a context with the provided pgx tx added.
 func WithPgx(ctx context.Context, tx pgx.Tx) context.Context {
 	return WithTx(ctx, tx)
@@ -30,17 +40,134 @@ func WithTx[T any](ctx context.Context, tx T) context.Context {
 // Tx is a generic transaction reader to support various transaction
 // types. E.g: Pgx, sql.Tx, Ent' model tx.
 func Tx[T any](ctx context.Context) T {
+	tx, err := TryTx[T](ctx)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return tx
+}
+
+// TryTx is like Tx but returns an error instead of panicking when there is no transaction
+// in the context, or when it is of the wrong type.
+func TryTx[T any](ctx context.Context) (tx T, err error) {
 	v := ctx.Value(ctxKey("tx"))
 	if v == nil {
-		panic("cltx: no tx in context")
+		return tx, ErrNoTx
 	}
 
 	tx, ok := v.(T)
 	if !ok {
 		var exp T
 
-		panic(fmt.Sprintf("cltx: wrong tx type in context, got: %T, expected: %T", v, exp))
+		return tx, fmt.Errorf("cltx: wrong tx type in context, got: %T, expected: %T", v, exp) //nolint:goerr113
 	}
 
-	return tx
+	return tx, nil
+}
+
+// Beginner begins pgx transactions, it is implemented by *pgxpool.Pool and *pgx.Conn.
+type Beginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// ErrNoParentHooks is returned when callbacks are registered in a savepoint of a transaction that was added to
+// the context without hooks, nothing would run them when the outer transaction completes.
+var ErrNoParentHooks = errors.New("cltx: callbacks in a savepoint of a tx without hooks")
+
+// execer is implemented by transactions that can create savepoints by executing statements, e.g. *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// RunInTx runs fn with a context that holds a new transaction. The transaction is committed if fn
+// returns without error and rolled back otherwise. If the context already holds a pgx transaction, or a
+// database/sql transaction, a savepoint is created in it instead and the options are ignored. Any other
+// transaction in the context (e.g. an Ent tx) is joined: fn runs in it as is, and its outcome is left to
+// the outer transaction. Callbacks that are registered in a savepoint are run when the outer transaction
+// completes, which requires the outer transaction to have hooks in the context (else ErrNoParentHooks is returned).
+func RunInTx(ctx context.Context, db Beginner, opts pgx.TxOptions, fn func(ctx context.Context) error) (err error) {
+	var (
+		txctx            context.Context
+		commit, rollback func(ctx context.Context) error
+		nested           bool
+	)
+
+	switch parent := ctx.Value(ctxKey("tx")).(type) {
+	case nil:
+		tx, err := db.BeginTx(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to begin tx: %w", err)
+		}
+
+		txctx, commit, rollback = WithPgx(ctx, tx), tx.Commit, tx.Rollback
+	case pgx.Tx:
+		tx, err := parent.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin tx: %w", err)
+		}
+
+		txctx, commit, rollback, nested = WithPgx(ctx, tx), tx.Commit, tx.Rollback, true
+	case execer:
+		if _, err := parent.ExecContext(ctx, `SAVEPOINT cltx_savepoint`); err != nil {
+			return fmt.Errorf("failed to begin tx: %w", err)
+		}
+
+		// savepoints with the same name nest, rolling back or releasing affects the most recent one
+		txctx, nested = ctx, true
+		commit = func(ctx context.Context) error {
+			_, err := parent.ExecContext(ctx, `RELEASE SAVEPOINT cltx_savepoint`)
+
+			return err //nolint:wrapcheck
+		}
+		rollback = func(ctx context.Context) error {
+			if _, err := parent.ExecContext(ctx, `ROLLBACK TO SAVEPOINT cltx_savepoint`); err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			return commit(ctx)
+		}
+	default:
+		return fn(ctx)
+	}
+
+	parentHooks, _ := ctx.Value(ctxKey("hooks")).(*Hooks)
+	txctx, hks := WithHooks(txctx)
+
+	var committed bool
+
+	defer func() {
+		if committed {
+			return
+		}
+
+		if rberr := rollback(ctx); rberr != nil && !errors.Is(rberr, pgx.ErrTxClosed) && !errors.Is(rberr, sql.ErrTxDone) {
+			err = errors.Join(err, fmt.Errorf("failed to rollback tx: %w", rberr))
+		}
+
+		hks.RunAfterRollback(ctx)
+	}()
+
+	if err = fn(txctx); err != nil {
+		return err
+	}
+
+	if nested && parentHooks == nil && hks.pending() {
+		return ErrNoParentHooks
+	}
+
+	if err = commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	committed = true
+
+	switch {
+	case nested && parentHooks != nil:
+		hks.merge(parentHooks) // released savepoint, outcome now depends on the outer tx
+	case !nested:
+		hks.RunAfterCommit(ctx)
+	}
+
+	return nil
 }
@@ -2,13 +2,17 @@ package cltx_test
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"path/filepath"
 	"testing"
 
 	"github.com/crewlinker/clgo/clpostgres"
 	"github.com/crewlinker/clgo/clpostgres/cltx"
 	"github.com/crewlinker/clgo/clzap"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -41,6 +45,14 @@ var _ = Describe("tx", func() {
 			Expect(func() { cltx.Pgx(ctx) }).To(Panic())
 		})
 
+		It("should return error without tx", func(ctx context.Context) {
+			_, err := cltx.TryPgx(ctx)
+			Expect(err).To(MatchError(cltx.ErrNoTx))
+
+			_, err = cltx.TryTx[*sql.Tx](cltx.WithTx(ctx, "foo"))
+			Expect(err).To(MatchError(MatchRegexp(`wrong tx type in context`)))
+		})
+
 		It("should add an remove tx from ctx", func(ctx context.Context) {
 			tx1, err := db.Begin(ctx)
 			Expect(err).ToNot(HaveOccurred())
@@ -52,4 +64,168 @@ var _ = Describe("tx", func() {
 			Expect(tx2).To(Equal(tx1))
 		})
 	})
+
+	Describe("run in tx", func() {
+		It("should commit and run after-commit hooks", func(ctx context.Context) {
+			var committed, rolledBack bool
+
+			Expect(cltx.RunInTx(ctx, db, pgx.TxOptions{}, func(ctx context.Context) error {
+				cltx.AfterCommit(ctx, func(context.Context) { committed = true })
+				cltx.AfterRollback(ctx, func(context.Context) { rolledBack = true })
+
+				_, err := cltx.Pgx(ctx).Exec(ctx, `SELECT 1`)
+
+				return err
+			})).To(Succeed())
+
+			Expect(committed).To(BeTrue())
+			Expect(rolledBack).To(BeFalse())
+		})
+
+		It("should rollback and run after-rollback hooks", func(ctx context.Context) {
+			var committed, rolledBack bool
+
+			Expect(cltx.RunInTx(ctx, db, pgx.TxOptions{}, func(ctx context.Context) error {
+				cltx.AfterCommit(ctx, func(context.Context) { committed = true })
+				cltx.AfterRollback(ctx, func(context.Context) { rolledBack = true })
+
+				return errors.New("fail")
+			})).To(MatchError("fail"))
+
+			Expect(committed).To(BeFalse())
+			Expect(rolledBack).To(BeTrue())
+		})
+
+		It("should use savepoints for nested transactions", func(ctx context.Context) {
+			var order []string
+
+			Expect(cltx.RunInTx(ctx, db, pgx.TxOptions{}, func(ctx context.Context) error {
+				outer := cltx.Pgx(ctx)
+				if _, err := outer.Exec(ctx, `CREATE TEMPORARY TABLE foo (id int) ON COMMIT DROP`); err != nil {
+					return err
+				}
+
+				Expect(cltx.RunInTx(ctx, db, pgx.TxOptions{}, func(ctx context.Context) error {
+					cltx.AfterRollback(ctx, func(context.Context) { order = append(order, "nested1_rollback") })
+					_, err := cltx.Pgx(ctx).Exec(ctx, `INSERT INTO foo VALUES (1)`)
+					Expect(err).ToNot(HaveOccurred())
+
+					return errors.New("fail")
+				})).To(MatchError("fail"))
+
+				Expect(cltx.RunInTx(ctx, db, pgx.TxOptions{}, func(ctx context.Context) error {
+					cltx.AfterCommit(ctx, func(context.Context) { order = append(order, "nested2_commit") })
+					_, err := cltx.Pgx(ctx).Exec(ctx, `INSERT INTO foo VALUES (2)`)
+
+					return err
+				})).To(Succeed())
+
+				Expect(order).To(Equal([]string{"nested1_rollback"}))
+
+				var ids []int
+				rows, err := outer.Query(ctx, `SELECT id FROM foo`)
+				Expect(err).ToNot(HaveOccurred())
+				ids, err = pgx.CollectRows(rows, pgx.RowTo[int])
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ids).To(Equal([]int{2}))
+
+				return nil
+			})).To(Succeed())
+
+			Expect(order).To(Equal([]string{"nested1_rollback", "nested2_commit"}))
+		})
+
+		It("should fail callbacks in a savepoint of a tx without hooks", func(ctx context.Context) {
+			tx, err := db.Begin(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(tx.Rollback)
+
+			ctx = cltx.WithPgx(ctx, tx)
+			Expect(cltx.RunInTx(ctx, db, pgx.TxOptions{}, func(ctx context.Context) error {
+				return nil
+			})).To(Succeed())
+
+			var committed bool
+			Expect(cltx.RunInTx(ctx, db, pgx.TxOptions{}, func(ctx context.Context) error {
+				cltx.AfterCommit(ctx, func(context.Context) { committed = true })
+
+				return nil
+			})).To(MatchError(cltx.ErrNoParentHooks))
+			Expect(committed).To(BeFalse())
+		})
+
+		It("should use savepoints in a database/sql tx", func(ctx context.Context) {
+			sdb := stdlib.OpenDBFromPool(db)
+			DeferCleanup(sdb.Close)
+
+			tx, err := sdb.BeginTx(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(func() { tx.Rollback() }) // fails after the commit below
+
+			ctx, hks := cltx.WithHooks(cltx.WithTx(ctx, tx))
+			_, err = tx.ExecContext(ctx, `CREATE TEMPORARY TABLE foo (id int) ON COMMIT DROP`)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cltx.RunInTx(ctx, db, pgx.TxOptions{}, func(ctx context.Context) error {
+				_, err := cltx.Tx[*sql.Tx](ctx).ExecContext(ctx, `INSERT INTO foo VALUES (1)`)
+				Expect(err).ToNot(HaveOccurred())
+
+				return errors.New("fail")
+			})).To(MatchError("fail"))
+
+			var committed bool
+			Expect(cltx.RunInTx(ctx, db, pgx.TxOptions{}, func(ctx context.Context) error {
+				cltx.AfterCommit(ctx, func(context.Context) { committed = true })
+				_, err := cltx.Tx[*sql.Tx](ctx).ExecContext(ctx, `INSERT INTO foo VALUES (2)`)
+
+				return err
+			})).To(Succeed())
+
+			var ids []int
+			rows, err := tx.QueryContext(ctx, `SELECT id FROM foo`)
+			Expect(err).ToNot(HaveOccurred())
+			for rows.Next() {
+				var id int
+				Expect(rows.Scan(&id)).To(Succeed())
+				ids = append(ids, id)
+			}
+			Expect(rows.Err()).ToNot(HaveOccurred())
+			Expect(ids).To(Equal([]int{2}))
+
+			Expect(committed).To(BeFalse())
+			Expect(tx.Commit()).To(Succeed())
+			hks.RunAfterCommit(ctx)
+			Expect(committed).To(BeTrue())
+		})
+
+		It("should join other transactions", func(ctx context.Context) {
+			type entTx struct{ name string }
+
+			ctx = cltx.WithTx(ctx, &entTx{name: "ent"})
+			Expect(cltx.RunInTx(ctx, db, pgx.TxOptions{}, func(ctx context.Context) error {
+				Expect(cltx.Tx[*entTx](ctx).name).To(Equal("ent"))
+
+				return nil
+			})).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("hooks", func() {
+	It("should panic without hooks", func(ctx context.Context) {
+		Expect(func() { cltx.AfterCommit(ctx, func(context.Context) {}) }).To(PanicWith(MatchRegexp(`no tx hooks`)))
+	})
+
+	It("should run hooks once", func(ctx context.Context) {
+		var n int
+
+		ctx, hks := cltx.WithHooks(ctx)
+		cltx.AfterCommit(ctx, func(context.Context) { n++ })
+		cltx.AfterRollback(ctx, func(context.Context) { n += 10 })
+
+		hks.RunAfterCommit(ctx)
+		hks.RunAfterCommit(ctx)
+		hks.RunAfterRollback(ctx)
+		Expect(n).To(Equal(1))
+	})
 })
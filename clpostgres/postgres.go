@@ -11,6 +11,7 @@ import (
 
 	"github.com/XSAM/otelsql"
 	"github.com/crewlinker/clgo/clconfig"
+	"github.com/crewlinker/clgo/clhealth"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
@@ -81,6 +82,19 @@ func New(
 	return sql.OpenDB(connr), nil
 }
 
+// NewChecker inits a health check that pings the connection pool with the name.
+func NewChecker(name string) func(pool *pgxpool.Pool) clhealth.Checker {
+	return func(pool *pgxpool.Pool) clhealth.Checker {
+		return clhealth.Checker{Name: moduleName + "_" + name, Check: func(ctx context.Context) error {
+			if err := pool.Ping(ctx); err != nil {
+				return fmt.Errorf("failed to ping: %w", err)
+			}
+
+			return nil
+		}}
+	}
+}
+
 // moduleName for naming conventions.
 const moduleName = "clpostgres"
 
@@ -153,6 +167,9 @@ func Provide() fx.Option {
 				return nil
 			}),
 		)),
+		// register the health checks of both pools
+		clhealth.ProvideChecker(NewChecker("ro"), `name:"ro"`),
+		clhealth.ProvideChecker(NewChecker("rw"), `name:"rw"`),
 	)
 }
 
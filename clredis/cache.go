@@ -0,0 +1,181 @@
+package clredis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"golang.org/x/sync/singleflight"
+)
+
+// Loader loads the value for a cache key when it is missing or (about to) expire.
+type Loader[T any] func(ctx context.Context) (T, error)
+
+// cacheEntry is stored in Redis, it includes what we need to decide on early expiry.
+type cacheEntry[T any] struct {
+	// V holds the cached value.
+	V T `json:"v"`
+	// D is how long it took to load the value, in ms.
+	D int64 `json:"d"`
+	// X is when the value expires, in unix ms.
+	X int64 `json:"x"`
+}
+
+// Cache is a typed read-through cache. Concurrent loads for the same key are de-duplicated within the process
+// and values are refreshed before they expire with a probability that increases as the expiry nears. This
+// prevents a stampede of loads when a popular key expires.
+type Cache[T any] struct {
+	cfg   Config
+	red   redis.UniversalClient
+	ns    string
+	ttl   time.Duration
+	group singleflight.Group
+	now   func() time.Time
+}
+
+// NewCache inits a cache for values in the namespace, values are cached for the ttl.
+func NewCache[T any](cfg Config, red redis.UniversalClient, namespace string, ttl time.Duration) *Cache[T] {
+	return &Cache[T]{cfg: cfg, red: red, ns: namespace, ttl: ttl, now: time.Now}
+}
+
+// Get the value for the key, or load it. The value is associated with the tags so it can be invalidated per tag.
+func (c *Cache[T]) Get(ctx context.Context, key string, load Loader[T], tags ...string) (val T, err error) {
+	data, err := c.red.Get(ctx, c.key(key)).Bytes()
+
+	switch {
+	case errors.Is(err, redis.Nil):
+	case err != nil:
+		return val, fmt.Errorf("failed to get: %w", err)
+	default:
+		var ent cacheEntry[T]
+		if err := json.Unmarshal(data, &ent); err != nil {
+			return val, fmt.Errorf("failed to decode entry: %w", err)
+		}
+
+		if !c.expiresEarly(ent) {
+			return ent.V, nil
+		}
+	}
+
+	// the load is shared with concurrent callers, so it must not be canceled when the caller that started it is.
+	resc := c.group.DoChan(key, func() (any, error) {
+		lctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.cfg.CacheLoadTimeout)
+		defer cancel()
+
+		return c.load(lctx, key, load, tags)
+	})
+
+	select {
+	case <-ctx.Done():
+		return val, fmt.Errorf("failed to wait for load: %w", ctx.Err())
+	case res := <-resc:
+		if res.Err != nil {
+			return val, res.Err //nolint:wrapcheck
+		}
+
+		return res.Val.(T), nil //nolint:forcetypeassert
+	}
+}
+
+// Invalidate the keys.
+func (c *Cache[T]) Invalidate(ctx context.Context, keys ...string) error {
+	if len(keys) < 1 {
+		return nil
+	}
+
+	// keys may be in different slots, so we delete them one-by-one in a pipeline.
+	if _, err := c.red.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, c.key(key))
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to delete keys: %w", err)
+	}
+
+	return nil
+}
+
+// InvalidateTags invalidates all the keys that are associated with any of the tags.
+func (c *Cache[T]) InvalidateTags(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		members, err := c.red.SMembers(ctx, c.tagKey(tag)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to get members of tag '%s': %w", tag, err)
+		}
+
+		if _, err := c.red.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, member := range members {
+				pipe.Del(ctx, member)
+			}
+
+			pipe.Del(ctx, c.tagKey(tag))
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to delete members of tag '%s': %w", tag, err)
+		}
+	}
+
+	return nil
+}
+
+// load the value and store it with the tags.
+func (c *Cache[T]) load(ctx context.Context, key string, load Loader[T], tags []string) (val T, err error) {
+	start := c.now()
+
+	if val, err = load(ctx); err != nil {
+		return val, fmt.Errorf("failed to load: %w", err)
+	}
+
+	ent := cacheEntry[T]{V: val, D: c.now().Sub(start).Milliseconds(), X: start.Add(c.ttl).UnixMilli()}
+
+	data, err := json.Marshal(ent)
+	if err != nil {
+		return val, fmt.Errorf("failed to encode entry: %w", err)
+	}
+
+	if _, err := c.red.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, c.key(key), data, c.ttl)
+
+		for _, tag := range tags {
+			pipe.SAdd(ctx, c.tagKey(tag), c.key(key))
+			pipe.PExpire(ctx, c.tagKey(tag), c.ttl)
+		}
+
+		return nil
+	}); err != nil {
+		return val, fmt.Errorf("failed to store entry: %w", err)
+	}
+
+	return val, nil
+}
+
+// expiresEarly implements probabilistic early expiration (XFetch), values that took longer to load are reloaded
+// earlier.
+func (c *Cache[T]) expiresEarly(ent cacheEntry[T]) bool {
+	if c.cfg.CacheEarlyExpiryBeta <= 0 {
+		return false
+	}
+
+	early := float64(ent.D) * c.cfg.CacheEarlyExpiryBeta * -math.Log(rand.Float64()) //nolint:gosec
+
+	return float64(c.now().UnixMilli())+early >= float64(ent.X)
+}
+
+func (c *Cache[T]) key(key string) string    { return "clredis:cache:" + c.ns + ":" + key }
+func (c *Cache[T]) tagKey(tag string) string { return "clredis:cache:" + c.ns + ":tag:" + tag }
+
+// ProvideCache provides a typed cache for the namespace.
+func ProvideCache[T any](namespace string, ttl time.Duration) fx.Option {
+	return fx.Provide(func(cfg Config, red redis.UniversalClient) *Cache[T] {
+		return NewCache[T](cfg, red, namespace, ttl)
+	})
+}
@@ -0,0 +1,95 @@
+package clredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crewlinker/clgo/clhealth"
+	"github.com/redis/go-redis/v9"
+)
+
+// NumSlots is the number of hash slots in a Redis cluster.
+const NumSlots = 16384
+
+// ErrSlotsNotCovered is returned by the health check when not all hash slots are served by a node.
+var ErrSlotsNotCovered = errors.New("clredis: not all cluster slots are covered")
+
+// Health reports on the Redis deployment.
+type Health struct {
+	// Cluster is true if the client is connected to a Redis cluster.
+	Cluster bool
+	// SlotsCovered is the number of hash slots that are served by a node, in cluster mode.
+	SlotsCovered int
+	// Nodes is the number of distinct nodes that serve slots, in cluster mode.
+	Nodes int
+}
+
+// HealthChecker checks the health of the Redis deployment.
+type HealthChecker struct {
+	red     redis.UniversalClient
+	cluster bool
+}
+
+// NewHealthChecker inits the health checker.
+func NewHealthChecker(opts *redis.UniversalOptions, red redis.UniversalClient) *HealthChecker {
+	// detect cluster mode by the concrete client. Sentinel mode with replica reads is also served by a cluster client,
+	// but a sentinel deployment has no slots to cover.
+	_, isCluster := red.(*redis.ClusterClient)
+
+	return &HealthChecker{red: red, cluster: isCluster && opts.MasterName == ""}
+}
+
+// Check pings Redis and, in cluster mode, reports the slot coverage. It returns ErrSlotsNotCovered (and the health)
+// if not all slots are covered.
+func (hc *HealthChecker) Check(ctx context.Context) (*Health, error) {
+	if err := hc.red.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to ping: %w", err)
+	}
+
+	health := &Health{Cluster: hc.cluster}
+	if !hc.cluster {
+		return health, nil
+	}
+
+	slots, err := hc.red.ClusterSlots(ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster slots: %w", err)
+	}
+
+	var covered [NumSlots]bool
+
+	nodes := map[string]struct{}{}
+
+	for _, slot := range slots {
+		if len(slot.Nodes) < 1 {
+			continue
+		}
+
+		nodes[slot.Nodes[0].Addr] = struct{}{}
+
+		for i := max(slot.Start, 0); i <= min(slot.End, NumSlots-1); i++ {
+			if !covered[i] {
+				covered[i] = true
+				health.SlotsCovered++
+			}
+		}
+	}
+
+	health.Nodes = len(nodes)
+	if health.SlotsCovered < NumSlots {
+		return health, fmt.Errorf("%w: %d of %d", ErrSlotsNotCovered, health.SlotsCovered, NumSlots)
+	}
+
+	return health, nil
+}
+
+// NewChecker registers the health check with clhealth, it fails when Redis can't be reached or when not all cluster
+// slots are covered.
+func NewChecker(hc *HealthChecker) clhealth.Checker {
+	return clhealth.Checker{Name: "redis", Check: func(ctx context.Context) error {
+		_, err := hc.Check(ctx)
+
+		return err
+	}}
+}
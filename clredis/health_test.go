@@ -0,0 +1,74 @@
+package clredis_test
+
+import (
+	"context"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/crewlinker/clgo/clredis"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+)
+
+var _ = Describe("health", func() {
+	var mr *miniredis.Miniredis
+
+	BeforeEach(func() {
+		mr = miniredis.NewMiniRedis()
+		Expect(mr.Start()).To(Succeed())
+		DeferCleanup(mr.Close)
+	})
+
+	It("should check a single node", func(ctx context.Context) {
+		var hc *clredis.HealthChecker
+		app := fx.New(fx.Populate(&hc), ProvideMini(mr))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+
+		health, err := hc.Check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(health.Cluster).To(BeFalse())
+	})
+
+	It("should report cluster slot coverage", func(ctx context.Context) {
+		var hc *clredis.HealthChecker
+		app := fx.New(fx.Populate(&hc), ProvideMini(mr, func(c *clredis.Config) {
+			c.Addrs = []string{mr.Addr(), mr.Addr()}
+		}))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+
+		health, err := hc.Check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(health.Cluster).To(BeTrue())
+		Expect(health.SlotsCovered).To(Equal(clredis.NumSlots))
+		Expect(health.Nodes).To(Equal(1))
+	})
+
+	It("should detect a cluster client with a single address", func(ctx context.Context) {
+		var hc *clredis.HealthChecker
+		app := fx.New(fx.Populate(&hc), ProvideMini(mr), fx.Decorate(func(red redis.UniversalClient) redis.UniversalClient {
+			return redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})
+		}))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+
+		health, err := hc.Check(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(health.Cluster).To(BeTrue())
+		Expect(health.SlotsCovered).To(Equal(clredis.NumSlots))
+	})
+
+	It("should fail when redis is down", func(ctx context.Context) {
+		var hc *clredis.HealthChecker
+		app := fx.New(fx.Populate(&hc), ProvideMini(mr))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+
+		mr.Close()
+
+		_, err := hc.Check(ctx)
+		Expect(err).To(MatchError(ContainSubstring("failed to ping")))
+	})
+})
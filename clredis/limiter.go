@@ -0,0 +1,127 @@
+package clredis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// gcraScript implements the generic cell rate algorithm. The theoretical arrival time (tat) is stored as seconds
+// relative to a recent epoch, to keep the precision of Lua's floats. The server's time is used so clients with
+// a skewed clock still agree.
+var gcraScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local period = tonumber(ARGV[3])
+local cost = tonumber(ARGV[4])
+
+local emission_interval = period / rate
+local increment = emission_interval * cost
+local burst_offset = emission_interval * burst
+
+local now = redis.call("TIME")
+now = (tonumber(now[1]) - 1700000000) + (tonumber(now[2]) / 1000000)
+
+local tat = redis.call("GET", KEYS[1])
+if not tat then
+	tat = now
+else
+	tat = tonumber(tat)
+end
+tat = math.max(tat, now)
+
+local new_tat = tat + increment
+local diff = now - (new_tat - burst_offset)
+local remaining = diff / emission_interval
+
+if remaining < 0 then
+	return {0, 0, tostring(-diff), tostring(tat - now)}
+end
+
+local reset_after = new_tat - now
+if reset_after > 0 then
+	redis.call("SET", KEYS[1], tostring(new_tat), "EX", math.ceil(reset_after))
+end
+
+return {cost, math.floor(remaining), "-1", tostring(reset_after)}`)
+
+// Limit describes a rate limit: a number of events per period, with a burst that may be used all at once.
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// PerSecond returns a limit of rate events per second, with an equal burst.
+func PerSecond(rate int) Limit { return Limit{Rate: rate, Period: time.Second, Burst: rate} }
+
+// PerMinute returns a limit of rate events per minute, with an equal burst.
+func PerMinute(rate int) Limit { return Limit{Rate: rate, Period: time.Minute, Burst: rate} }
+
+// LimitResult describes the result of a rate limited event.
+type LimitResult struct {
+	// Allowed is the number of events that were allowed, zero if the event was limited.
+	Allowed int
+	// Remaining is the number of events that may still happen right now.
+	Remaining int
+	// RetryAfter is the duration after which the event would be allowed, -1 if it was allowed.
+	RetryAfter time.Duration
+	// ResetAfter is the duration after which the limit is back at its full burst.
+	ResetAfter time.Duration
+}
+
+// Limiter implements a distributed rate limiter with the GCRA algorithm. Each key is a single Redis key so it
+// works in cluster mode.
+type Limiter struct {
+	red redis.UniversalClient
+}
+
+// NewLimiter inits the limiter.
+func NewLimiter(red redis.UniversalClient) *Limiter {
+	return &Limiter{red: red}
+}
+
+// Allow is shorthand for AllowN(ctx, key, limit, 1).
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) (*LimitResult, error) {
+	return l.AllowN(ctx, key, limit, 1)
+}
+
+// AllowN reports whether n events may happen for the key, and records them if they may.
+func (l *Limiter) AllowN(ctx context.Context, key string, limit Limit, n int) (*LimitResult, error) {
+	vals, err := gcraScript.Run(ctx, l.red, []string{"clredis:rate:{" + key + "}"},
+		limit.Burst, limit.Rate, limit.Period.Seconds(), n).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run gcra script: %w", err)
+	}
+
+	res := &LimitResult{Allowed: int(vals[0].(int64)), Remaining: int(vals[1].(int64))} //nolint:forcetypeassert
+
+	if res.RetryAfter, err = parseSeconds(vals[2]); err != nil {
+		return nil, fmt.Errorf("failed to parse retry after: %w", err)
+	}
+
+	if res.ResetAfter, err = parseSeconds(vals[3]); err != nil {
+		return nil, fmt.Errorf("failed to parse reset after: %w", err)
+	}
+
+	return res, nil
+}
+
+// parseSeconds parses a string of float seconds as returned by the script.
+func parseSeconds(v any) (time.Duration, error) {
+	s, _ := v.(string)
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse float: %w", err)
+	}
+
+	if f == -1 {
+		return -1, nil
+	}
+
+	return time.Duration(f * float64(time.Second)), nil
+}
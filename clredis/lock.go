@@ -0,0 +1,216 @@
+package clredis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crewlinker/clgo/clzap"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// ErrNotObtained is returned when the lock is held by someone else.
+var ErrNotObtained = errors.New("clredis: lock not obtained")
+
+// ErrLockNotHeld is returned when the lock is refreshed or released but it is no longer held.
+var ErrLockNotHeld = errors.New("clredis: lock not held")
+
+// obtainScript sets the lock if it doesn't exist, and increments the fencing token if it did.
+var obtainScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0`)
+
+// refreshScript extends the lock's expiry, only if the lock is still held.
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// releaseScript deletes the lock, only if the lock is still held.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// ErrInvalidLockKey is returned when the lock key contains braces, they would change the key's hash tag.
+var ErrInvalidLockKey = errors.New("clredis: lock key must not contain '{' or '}'")
+
+// LockOption configures the obtaining of a lock.
+type LockOption func(*lockOpts)
+
+// LockTTL configures how long the lock is held before it expires.
+func LockTTL(d time.Duration) LockOption {
+	return func(o *lockOpts) { o.ttl = d }
+}
+
+// LockRetry configures obtain to retry with the interval until the context is done.
+func LockRetry(interval time.Duration) LockOption {
+	return func(o *lockOpts) { o.retry = interval }
+}
+
+// LockAutoExtend will extend the lock in the background (at half the ttl) until it is released.
+func LockAutoExtend() LockOption {
+	return func(o *lockOpts) { o.extend = true }
+}
+
+type lockOpts struct {
+	ttl    time.Duration
+	retry  time.Duration
+	extend bool
+}
+
+// Locker provides distributed locks. The keys of a lock share a hash tag so it works in cluster mode.
+type Locker struct {
+	cfg  Config
+	logs *zap.Logger
+	red  redis.UniversalClient
+}
+
+// NewLocker inits the locker.
+func NewLocker(cfg Config, logs *zap.Logger, red redis.UniversalClient) *Locker {
+	return &Locker{cfg: cfg, logs: logs.Named("locker"), red: red}
+}
+
+// Obtain the lock with the key. It returns ErrNotObtained if the lock is held by someone else.
+func (l *Locker) Obtain(ctx context.Context, key string, os ...LockOption) (*Lock, error) {
+	// the lock and fence keys must hash to the same slot, braces in the key would change which part is hashed.
+	if strings.ContainsAny(key, "{}") {
+		return nil, ErrInvalidLockKey
+	}
+
+	opts := &lockOpts{ttl: l.cfg.LockDefaultTTL}
+	for _, o := range os {
+		o(opts)
+	}
+
+	var rnd [16]byte
+	if _, err := rand.Read(rnd[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate lock value: %w", err)
+	}
+
+	lck := &Lock{
+		red:   l.red,
+		logs:  clzap.Log(ctx, l.logs),
+		key:   "clredis:lock:{" + key + "}",
+		value: hex.EncodeToString(rnd[:]),
+		ttl:   opts.ttl,
+		done:  make(chan struct{}),
+	}
+
+	for {
+		token, err := obtainScript.Run(ctx, l.red,
+			[]string{lck.key, lck.key + ":fence"}, lck.value, opts.ttl.Milliseconds()).Int64()
+		if err != nil {
+			return nil, fmt.Errorf("failed to run obtain script: %w", err)
+		}
+
+		if token > 0 {
+			lck.token = token
+
+			break
+		}
+
+		if opts.retry <= 0 {
+			return nil, ErrNotObtained
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrNotObtained, ctx.Err())
+		case <-time.After(opts.retry):
+		}
+	}
+
+	if opts.extend {
+		go lck.extend()
+	}
+
+	return lck, nil
+}
+
+// Lock is an obtained distributed lock.
+type Lock struct {
+	red   redis.UniversalClient
+	logs  *zap.Logger
+	key   string
+	value string
+	token int64
+	ttl   time.Duration
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// Token returns the fencing token of the lock. It increases every time the lock is obtained so resources that are
+// protected by the lock can reject writes from holders that lost the lock (e.g. after a long gc pause).
+func (lck *Lock) Token() int64 { return lck.token }
+
+// Done returns a channel that is closed when the lock is released, or when auto-extension failed to extend it.
+func (lck *Lock) Done() <-chan struct{} { return lck.done }
+
+// Refresh extends the lock with the ttl.
+func (lck *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	res, err := refreshScript.Run(ctx, lck.red, []string{lck.key}, lck.value, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to run refresh script: %w", err)
+	}
+
+	if res == 0 {
+		return ErrLockNotHeld
+	}
+
+	return nil
+}
+
+// Release the lock, it stops any auto-extension.
+func (lck *Lock) Release(ctx context.Context) error {
+	lck.doneOnce.Do(func() { close(lck.done) })
+
+	res, err := releaseScript.Run(ctx, lck.red, []string{lck.key}, lck.value).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to run release script: %w", err)
+	}
+
+	if res == 0 {
+		return ErrLockNotHeld
+	}
+
+	return nil
+}
+
+// extend the lock until it is released or lost.
+func (lck *Lock) extend() {
+	ticker := time.NewTicker(lck.ttl / 2) //nolint:gomnd
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lck.done:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), lck.ttl/2) //nolint:gomnd
+		err := lck.Refresh(ctx, lck.ttl)
+
+		cancel()
+
+		if err != nil {
+			lck.logs.Error("failed to auto-extend lock, considering it lost",
+				zap.String("key", lck.key), zap.Error(err))
+			lck.doneOnce.Do(func() { close(lck.done) })
+
+			return
+		}
+	}
+}
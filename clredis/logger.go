@@ -2,8 +2,16 @@ package clredis
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
+	"github.com/crewlinker/clgo/clzap"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
@@ -19,3 +27,135 @@ func NewLogger(logs *zap.Logger) *Logger {
 func (l Logger) Printf(_ context.Context, format string, v ...interface{}) {
 	l.logs.Printf(format, v...)
 }
+
+// LogHook logs the commands and pipelines of a client with the logger from the context. Commands are logged at the
+// debug level, slow commands as a warning and failed commands as an error.
+type LogHook struct {
+	cfg  Config
+	logs *zap.Logger
+}
+
+// NewLogHook inits the logging hook.
+func NewLogHook(cfg Config, logs *zap.Logger) *LogHook {
+	return &LogHook{cfg: cfg, logs: logs}
+}
+
+// DialHook implements redis.Hook.
+func (h *LogHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+// ProcessHook implements redis.Hook.
+func (h *LogHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		took := time.Since(start)
+
+		fields := []zap.Field{zap.String("command", cmd.FullName()), zap.Duration("duration", took)}
+		if key, ok := commandKey(cmd); ok {
+			fields = append(fields, zap.String("key", h.key(key)))
+		}
+
+		h.log(ctx, took, isBlocking(cmd), err, "redis command", fields)
+
+		return err
+	}
+}
+
+// ProcessPipelineHook implements redis.Hook.
+func (h *LogHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		took := time.Since(start)
+
+		names, blocking := make([]string, len(cmds)), false
+		for i, cmd := range cmds {
+			names[i], blocking = cmd.FullName(), blocking || isBlocking(cmd)
+
+			// the pipeline itself may succeed while individual commands failed
+			if cerr := cmd.Err(); err == nil && cerr != nil && !errors.Is(cerr, redis.Nil) {
+				err = cerr
+			}
+		}
+
+		h.log(ctx, took, blocking, err, "redis pipeline", []zap.Field{
+			zap.Strings("commands", names), zap.Duration("duration", took),
+		})
+
+		return err
+	}
+}
+
+// log at the level that fits the outcome.
+func (h *LogHook) log(ctx context.Context, took time.Duration, blocking bool, err error, msg string, fs []zap.Field) {
+	logs := clzap.Log(ctx, h.logs)
+
+	switch {
+	case err != nil && !errors.Is(err, redis.Nil):
+		logs.Error(msg+" failed", append(fs, zap.Error(err))...)
+	case !blocking && h.cfg.SlowCommandThreshold > 0 && took >= h.cfg.SlowCommandThreshold:
+		logs.Warn(msg+" was slow", fs...)
+	default:
+		logs.Debug(msg, fs...)
+	}
+}
+
+// key returns the key for logging, it is replaced by a short hash when redaction is enabled so it can still be
+// correlated across log lines.
+func (h *LogHook) key(key string) string {
+	if !h.cfg.LogRedactKeys {
+		return key
+	}
+
+	sum := sha256.Sum256([]byte(key))
+
+	return "sha256:" + hex.EncodeToString(sum[:4])
+}
+
+// commandKey returns the (first) key of a command, this is a heuristic that covers the common commands.
+func commandKey(cmd redis.Cmder) (string, bool) {
+	args := cmd.Args()
+	pos := 1
+
+	switch cmd.Name() {
+	case "ping", "echo", "info", "hello", "auth", "select", "client", "cluster", "command", "config", "time",
+		"script", "function", "dbsize", "flushdb", "flushall", "readonly", "readwrite", "quit":
+		return "", false
+	case "xgroup", "xinfo", "object", "memory":
+		pos = 2
+	case "eval", "evalsha", "eval_ro", "evalsha_ro", "fcall", "fcall_ro":
+		if len(args) < 3 || fmt.Sprint(args[2]) == "0" {
+			return "", false
+		}
+
+		pos = 3
+	case "xread", "xreadgroup":
+		for i, arg := range args {
+			if s, ok := arg.(string); ok && strings.EqualFold(s, "streams") {
+				pos = i + 1
+			}
+		}
+	}
+
+	if len(args) <= pos {
+		return "", false
+	}
+
+	return fmt.Sprint(args[pos]), true
+}
+
+// isBlocking returns whether the command blocks by design, these are not reported as slow.
+func isBlocking(cmd redis.Cmder) bool {
+	switch cmd.Name() {
+	case "blpop", "brpop", "brpoplpush", "blmove", "blmpop", "bzpopmin", "bzpopmax", "bzmpop", "wait":
+		return true
+	case "xread", "xreadgroup":
+		for _, arg := range cmd.Args() {
+			if s, ok := arg.(string); ok && strings.EqualFold(s, "block") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
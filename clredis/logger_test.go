@@ -0,0 +1,70 @@
+package clredis_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/crewlinker/clgo/clredis"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var _ = Describe("log hook", func() {
+	var mr *miniredis.Miniredis
+	var red redis.UniversalClient
+	var obs *observer.ObservedLogs
+
+	start := func(ctx context.Context, cfgf func(*clredis.Config)) {
+		app := fx.New(fx.Populate(&red, &obs), ProvideMini(mr, cfgf))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	}
+
+	BeforeEach(func() {
+		mr = miniredis.NewMiniRedis()
+		Expect(mr.Start()).To(Succeed())
+		DeferCleanup(mr.Close)
+	})
+
+	It("should log slow commands and pipelines", func(ctx context.Context) {
+		start(ctx, func(c *clredis.Config) { c.SlowCommandThreshold = time.Nanosecond })
+
+		Expect(red.Set(ctx, "foo", "bar", 0).Err()).To(Succeed())
+		_, err := red.Pipelined(ctx, func(p redis.Pipeliner) error {
+			p.Get(ctx, "foo")
+			p.Incr(ctx, "cnt")
+
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		cmds := obs.FilterMessage("redis command was slow").FilterFieldKey("key").All()
+		Expect(cmds).To(HaveLen(1))
+		Expect(cmds[0].ContextMap()).To(HaveKeyWithValue("command", "set"))
+		Expect(cmds[0].ContextMap()).To(HaveKeyWithValue("key", "foo"))
+		Expect(cmds[0].ContextMap()).To(HaveKey("duration"))
+
+		pipes := obs.FilterMessage("redis pipeline was slow").All()
+		Expect(pipes).To(HaveLen(1))
+		Expect(pipes[0].ContextMap()).To(HaveKeyWithValue("commands", ConsistOf("get", "incr")))
+	})
+
+	It("should log failed commands with redacted keys", func(ctx context.Context) {
+		start(ctx, func(c *clredis.Config) { c.LogRedactKeys = true })
+
+		Expect(red.Set(ctx, "secret-key", "bar", 0).Err()).To(Succeed())
+		Expect(red.LPush(ctx, "secret-key", "x").Err()).To(HaveOccurred())
+		Expect(red.Get(ctx, "missing").Err()).To(MatchError(redis.Nil))
+
+		failed := obs.FilterMessage("redis command failed").All()
+		Expect(failed).To(HaveLen(1))
+		Expect(failed[0].ContextMap()).To(HaveKeyWithValue("command", "lpush"))
+		Expect(failed[0].ContextMap()).To(HaveKeyWithValue("key", HavePrefix("sha256:")))
+		Expect(failed[0].ContextMap()).To(HaveKeyWithValue("error", ContainSubstring("WRONGTYPE")))
+		Expect(obs.FilterMessage("redis command was slow").Len()).To(BeZero())
+	})
+})
@@ -0,0 +1,282 @@
+package clredis_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/crewlinker/clgo/clredis"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+)
+
+// ProvideMini provides the redis module against a local stand-in redis server.
+func ProvideMini(mr *miniredis.Miniredis, cfgfs ...func(*clredis.Config)) fx.Option {
+	return fx.Options(
+		fx.Decorate(func(c clredis.Config) clredis.Config {
+			c.Addrs = []string{mr.Addr()}
+			c.LockDefaultTTL = time.Second
+
+			for _, f := range cfgfs {
+				f(&c)
+			}
+
+			return c
+		}), clredis.TestProvide(), clzap.TestProvide())
+}
+
+var _ = Describe("primitives", func() {
+	var mr *miniredis.Miniredis
+	var lckr *clredis.Locker
+	var lmtr *clredis.Limiter
+	var cache *clredis.Cache[string]
+
+	BeforeEach(func(ctx context.Context) {
+		mr = miniredis.NewMiniRedis()
+		Expect(mr.Start()).To(Succeed())
+		DeferCleanup(mr.Close)
+
+		app := fx.New(fx.Populate(&lckr, &lmtr, &cache),
+			ProvideMini(mr), clredis.ProvideCache[string]("test", time.Minute))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	Describe("lock", func() {
+		It("should obtain and release with fencing tokens", func(ctx context.Context) {
+			lck1, err := lckr.Obtain(ctx, "foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lck1.Token()).To(Equal(int64(1)))
+
+			_, err = lckr.Obtain(ctx, "foo")
+			Expect(err).To(MatchError(clredis.ErrNotObtained))
+
+			Expect(lck1.Release(ctx)).To(Succeed())
+			Eventually(lck1.Done()).Should(BeClosed())
+			Expect(lck1.Release(ctx)).To(MatchError(clredis.ErrLockNotHeld))
+
+			lck2, err := lckr.Obtain(ctx, "foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lck2.Token()).To(Equal(int64(2)))
+			Expect(mr.Keys()).To(ConsistOf("clredis:lock:{foo}", "clredis:lock:{foo}:fence"))
+		})
+
+		It("should expire and refresh", func(ctx context.Context) {
+			lck, err := lckr.Obtain(ctx, "foo", clredis.LockTTL(time.Second))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(lck.Refresh(ctx, time.Second*10)).To(Succeed())
+			mr.FastForward(time.Second * 5)
+			Expect(mr.Exists("clredis:lock:{foo}")).To(BeTrue())
+
+			mr.FastForward(time.Second * 6)
+			Expect(lck.Refresh(ctx, time.Second)).To(MatchError(clredis.ErrLockNotHeld))
+		})
+
+		It("should retry until obtained", func(ctx context.Context) {
+			lck1, err := lckr.Obtain(ctx, "foo")
+			Expect(err).ToNot(HaveOccurred())
+
+			go func() {
+				time.Sleep(time.Millisecond * 50)
+				lck1.Release(context.Background())
+			}()
+
+			lck2, err := lckr.Obtain(ctx, "foo", clredis.LockRetry(time.Millisecond*10))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lck2.Token()).To(Equal(int64(2)))
+
+			tctx, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+			defer cancel()
+
+			_, err = lckr.Obtain(tctx, "foo", clredis.LockRetry(time.Millisecond*10))
+			Expect(err).To(MatchError(clredis.ErrNotObtained))
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		})
+
+		It("should reject keys with braces", func(ctx context.Context) {
+			_, err := lckr.Obtain(ctx, "}foo")
+			Expect(err).To(MatchError(clredis.ErrInvalidLockKey))
+			Expect(mr.Keys()).To(BeEmpty())
+		})
+
+		It("should auto extend", func(ctx context.Context) {
+			lck, err := lckr.Obtain(ctx, "foo", clredis.LockTTL(time.Millisecond*200), clredis.LockAutoExtend())
+			Expect(err).ToNot(HaveOccurred())
+
+			// miniredis' time only moves when fast-forwarded, the lock only outlives its ttl when it is extended
+			for range 3 {
+				mr.FastForward(time.Millisecond * 150)
+
+				Eventually(func() time.Duration {
+					return mr.TTL("clredis:lock:{foo}")
+				}).Should(BeNumerically("==", time.Millisecond*200))
+			}
+
+			Expect(mr.Exists("clredis:lock:{foo}")).To(BeTrue())
+			Consistently(lck.Done()).ShouldNot(BeClosed())
+
+			By("losing the lock")
+			mr.Del("clredis:lock:{foo}")
+			Eventually(lck.Done()).Should(BeClosed())
+		})
+	})
+
+	Describe("limiter", func() {
+		It("should limit with burst", func(ctx context.Context) {
+			lim := clredis.Limit{Rate: 10, Period: time.Minute, Burst: 3}
+
+			for i := 0; i < 3; i++ {
+				res, err := lmtr.Allow(ctx, "foo", lim)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.Allowed).To(Equal(1))
+				Expect(res.Remaining).To(Equal(2 - i))
+				Expect(res.RetryAfter).To(Equal(time.Duration(-1)))
+			}
+
+			res, err := lmtr.Allow(ctx, "foo", lim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Allowed).To(Equal(0))
+			Expect(res.RetryAfter).To(BeNumerically("~", time.Second*6, time.Second))
+			Expect(res.ResetAfter).To(BeNumerically("~", time.Second*18, time.Second))
+
+			res, err = lmtr.Allow(ctx, "bar", lim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Allowed).To(Equal(1))
+		})
+
+		It("should allow after the emission interval", func(ctx context.Context) {
+			mr.SetTime(time.Unix(1800000000, 0))
+
+			lim := clredis.PerSecond(1)
+			res, err := lmtr.Allow(ctx, "foo", lim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Allowed).To(Equal(1))
+
+			res, err = lmtr.Allow(ctx, "foo", lim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Allowed).To(Equal(0))
+
+			mr.SetTime(time.Unix(1800000001, 0))
+
+			res, err = lmtr.Allow(ctx, "foo", lim)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Allowed).To(Equal(1))
+		})
+	})
+
+	Describe("cache", func() {
+		It("should read through and invalidate", func(ctx context.Context) {
+			var loads atomic.Int64
+			load := func(ctx context.Context) (string, error) {
+				loads.Add(1)
+
+				return "bar", nil
+			}
+
+			for i := 0; i < 3; i++ {
+				val, err := cache.Get(ctx, "foo", load, "tag1")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(val).To(Equal("bar"))
+			}
+
+			Expect(loads.Load()).To(Equal(int64(1)))
+			Expect(mr.TTL("clredis:cache:test:foo")).To(Equal(time.Minute))
+
+			Expect(cache.Invalidate(ctx, "foo")).To(Succeed())
+			_, err := cache.Get(ctx, "foo", load, "tag1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loads.Load()).To(Equal(int64(2)))
+
+			Expect(cache.InvalidateTags(ctx, "tag1")).To(Succeed())
+			Expect(mr.Keys()).To(BeEmpty())
+		})
+
+		It("should de-duplicate concurrent loads", func(ctx context.Context) {
+			var loads atomic.Int64
+			load := func(ctx context.Context) (string, error) {
+				loads.Add(1)
+				time.Sleep(time.Millisecond * 50)
+
+				return "bar", nil
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					val, err := cache.Get(ctx, "foo", load)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(val).To(Equal("bar"))
+				}()
+			}
+
+			wg.Wait()
+			Expect(loads.Load()).To(Equal(int64(1)))
+		})
+
+		It("should reload early when expiry nears", func(ctx context.Context) {
+			Expect(mr.Set("clredis:cache:test:foo", fmt.Sprintf(`{"v":"old","d":1000000,"x":%d}`,
+				time.Now().Add(time.Second).UnixMilli()))).To(Succeed())
+
+			val, err := cache.Get(ctx, "foo", func(ctx context.Context) (string, error) { return "new", nil })
+			Expect(err).ToNot(HaveOccurred())
+			Expect(val).To(Equal("new"))
+
+			Expect(mr.Set("clredis:cache:test:foo", fmt.Sprintf(`{"v":"old","d":0,"x":%d}`,
+				time.Now().Add(time.Minute).UnixMilli()))).To(Succeed())
+
+			val, err = cache.Get(ctx, "foo", func(ctx context.Context) (string, error) { return "new", nil })
+			Expect(err).ToNot(HaveOccurred())
+			Expect(val).To(Equal("old"))
+		})
+
+		It("should not cancel a shared load when its caller goes away", func(ctx context.Context) {
+			started, release := make(chan struct{}), make(chan struct{})
+			load := func(ctx context.Context) (string, error) {
+				close(started)
+				<-release
+
+				return "bar", ctx.Err()
+			}
+
+			cctx, cancel := context.WithCancel(ctx)
+			errc := make(chan error, 1)
+
+			go func() {
+				_, err := cache.Get(cctx, "foo", load)
+				errc <- err
+			}()
+
+			<-started
+			cancel()
+			Eventually(errc).Should(Receive(MatchError(context.Canceled)))
+
+			go func() {
+				time.Sleep(time.Millisecond * 50)
+				close(release)
+			}()
+
+			val, err := cache.Get(ctx, "foo", load)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(val).To(Equal("bar"))
+		})
+
+		It("should return load errors", func(ctx context.Context) {
+			_, err := cache.Get(ctx, "foo", func(ctx context.Context) (string, error) {
+				return "", errors.New("boom")
+			})
+			Expect(err).To(MatchError(`failed to load: boom`))
+		})
+	})
+})
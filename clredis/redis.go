@@ -4,10 +4,15 @@ package clredis
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/crewlinker/clgo/clconfig"
+	"github.com/crewlinker/clgo/clhealth"
 	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/metric"
@@ -24,54 +29,161 @@ type Config struct {
 	Username string `env:"USERNAME"`
 	// Password for authenticated configuration
 	Password string `env:"PASSWORD"`
-	// Enable tls
+	// DB selects the database index, it is not supported in cluster mode
+	DB int `env:"DB" envDefault:"0"`
+	// SentinelMasterName enables sentinel mode, the addresses are then the sentinel addresses
+	SentinelMasterName string `env:"SENTINEL_MASTER_NAME"`
+	// SentinelUsername for authenticating with the sentinels, if they require it
+	SentinelUsername string `env:"SENTINEL_USERNAME"`
+	// SentinelPassword for authenticating with the sentinels, if they require it
+	SentinelPassword string `env:"SENTINEL_PASSWORD"`
+	// ReadFromReplicas routes read-only commands to replicas, in sentinel mode these are routed randomly unless
+	// RouteByLatency is set
+	ReadFromReplicas bool `env:"READ_FROM_REPLICAS" envDefault:"false"`
+	// RouteByLatency routes read-only commands to the node with the lowest latency, it implies ReadFromReplicas
+	RouteByLatency bool `env:"ROUTE_BY_LATENCY" envDefault:"false"`
+	// RouteRandomly routes read-only commands to a random node, it implies ReadFromReplicas
+	RouteRandomly bool `env:"ROUTE_RANDOMLY" envDefault:"false"`
+	// PoolSize is the maximum number of connections (per node), zero uses the client's default
+	PoolSize int `env:"POOL_SIZE" envDefault:"0"`
+	// MinIdleConns is the minimum number of idle connections that are kept open
+	MinIdleConns int `env:"MIN_IDLE_CONNS" envDefault:"0"`
+	// PoolTimeout is how long to wait for a connection when all are busy, zero uses the client's default
+	PoolTimeout time.Duration `env:"POOL_TIMEOUT" envDefault:"0"`
+	// DialTimeout for establishing new connections, zero uses the client's default
+	DialTimeout time.Duration `env:"DIAL_TIMEOUT" envDefault:"0"`
+	// ReadTimeout for socket reads, zero uses the client's default
+	ReadTimeout time.Duration `env:"READ_TIMEOUT" envDefault:"0"`
+	// WriteTimeout for socket writes, zero uses the client's default
+	WriteTimeout time.Duration `env:"WRITE_TIMEOUT" envDefault:"0"`
+	// Enable tls, the server certificate is always verified
 	EnableTLS bool `env:"ENABLE_TLS" envDefault:"false"`
-	// Setting this enables TLS connections
+	// TLSServerName overwrites the name that is verified, by default the host of the address is used
 	TLSServerName string `env:"TLS_SERVER_NAME"`
+	// TLSCAFile is a PEM bundle with additional certificate authorities to trust, e.g. for managed Redis
+	TLSCAFile string `env:"TLS_CA_FILE"`
 	// ClientName allows the application to indicate its name so connections can be more easily debugged
 	ClientName string `env:"CLIENT_NAME" envDefault:"unknown"`
+	// SlowCommandThreshold is the duration after which commands are logged as slow, zero disables it.
+	SlowCommandThreshold time.Duration `env:"SLOW_COMMAND_THRESHOLD" envDefault:"100ms"`
+	// LogRedactKeys replaces the keys in command logs with a short hash.
+	LogRedactKeys bool `env:"LOG_REDACT_KEYS" envDefault:"false"`
+	// LockDefaultTTL is how long locks are held before they expire, unless specified otherwise.
+	LockDefaultTTL time.Duration `env:"LOCK_DEFAULT_TTL" envDefault:"10s"`
+	// CacheEarlyExpiryBeta scales how early cached values are re-loaded before they expire, 0 disables it.
+	CacheEarlyExpiryBeta float64 `env:"CACHE_EARLY_EXPIRY_BETA" envDefault:"1"`
+	// CacheLoadTimeout bounds a (shared) load, it doesn't end when the caller that started it goes away.
+	CacheLoadTimeout time.Duration `env:"CACHE_LOAD_TIMEOUT" envDefault:"10s"`
+
+	// StreamGroup is the consumer group that stream handlers read in.
+	StreamGroup string `env:"STREAM_GROUP" envDefault:"default"`
+	// StreamConsumer names this consumer in the group, it defaults to the hostname and process id.
+	StreamConsumer string `env:"STREAM_CONSUMER"`
+	// StreamBatchSize is the maximum number of messages read (or claimed) at once.
+	StreamBatchSize int64 `env:"STREAM_BATCH_SIZE" envDefault:"10"`
+	// StreamBlock is how long a read blocks while waiting for new messages.
+	StreamBlock time.Duration `env:"STREAM_BLOCK" envDefault:"1s"`
+	// StreamClaimInterval is how often pending messages are checked for being claimed.
+	StreamClaimInterval time.Duration `env:"STREAM_CLAIM_INTERVAL" envDefault:"10s"`
+	// StreamClaimMinIdle is how long a message must be pending before it is claimed (and retried).
+	StreamClaimMinIdle time.Duration `env:"STREAM_CLAIM_MIN_IDLE" envDefault:"30s"`
+	// StreamMaxRetries is the number of deliveries after which a failing message is moved to the dead-letter stream.
+	StreamMaxRetries int64 `env:"STREAM_MAX_RETRIES" envDefault:"5"`
 }
 
 // NewOptions parses our environment config into options for the Redis client.
 func NewOptions(cfg Config, logs *zap.Logger) (*redis.UniversalOptions, error) {
 	opts := &redis.UniversalOptions{
 		Addrs:    cfg.Addrs,
+		DB:       cfg.DB,
 		Username: cfg.Username,
 		Password: cfg.Password,
 
-		// note: taken from: https://t.ly/cixv
-		ReadOnly:       false,
-		RouteRandomly:  false,
-		RouteByLatency: false,
+		MasterName:       cfg.SentinelMasterName,
+		SentinelUsername: cfg.SentinelUsername,
+		SentinelPassword: cfg.SentinelPassword,
+
+		// note: see https://t.ly/cixv, the client enables ReadOnly itself when routing
+		ReadOnly:       cfg.ReadFromReplicas || cfg.RouteByLatency || cfg.RouteRandomly,
+		RouteRandomly:  cfg.RouteRandomly,
+		RouteByLatency: cfg.RouteByLatency,
+
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+		PoolTimeout:  cfg.PoolTimeout,
+		DialTimeout:  cfg.DialTimeout,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
 
 		ClientName: cfg.ClientName,
 	}
 
-	// unfortunately, the redis client only support a global logger, so
-	// we set it during configuration
+	// the redis client's own (internal) logging only supports a global logger. Commands are logged per client by
+	// the LogHook, so this is only a fallback.
 	redis.SetLogger(NewLogger(logs.Named("client")))
 
-	// enable tls if available, potentially with server name checking
+	// enable tls if configured, the server name defaults to the host of each (node) address
 	if cfg.EnableTLS {
 		opts.TLSConfig = &tls.Config{
-			InsecureSkipVerify: true, //nolint:gosec
-			MinVersion:         tls.VersionTLS12,
+			MinVersion: tls.VersionTLS12,
+			ServerName: cfg.TLSServerName,
 		}
 
-		if cfg.TLSServerName != "" {
-			opts.TLSConfig.InsecureSkipVerify = false
-			opts.TLSConfig.ServerName = cfg.TLSServerName
+		if cfg.TLSCAFile != "" {
+			pool, err := loadCAPool(cfg.TLSCAFile)
+			if err != nil {
+				return nil, err
+			}
+
+			opts.TLSConfig.RootCAs = pool
 		}
 	}
 
 	return opts, nil
 }
 
+// loadCAPool adds the certificate authorities in the PEM file to the system's pool.
+func loadCAPool(file string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool() // e.g. on platforms without a system pool
+	}
+
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: %s", ErrNoCACertificates, file)
+	}
+
+	return pool, nil
+}
+
+// ErrNoCACertificates is returned when the configured CA file contains no certificates.
+var ErrNoCACertificates = errors.New("clredis: no certificates in CA file")
+
+// newClient inits the client for the options. Unlike the universal client, reading from replicas in sentinel
+// mode is supported by using a failover cluster client.
+func newClient(opts *redis.UniversalOptions) redis.UniversalClient {
+	if opts.MasterName == "" || !opts.ReadOnly {
+		return redis.NewUniversalClient(opts)
+	}
+
+	fopts := opts.Failover()
+	fopts.RouteByLatency = opts.RouteByLatency
+	fopts.RouteRandomly = opts.RouteRandomly || !opts.RouteByLatency
+
+	return redis.NewFailoverClusterClient(fopts)
+}
+
 // New inits a universal client and instruments it if available.
 func New(
-	opts *redis.UniversalOptions, _ *zap.Logger, tp trace.TracerProvider, mtr metric.MeterProvider,
+	cfg Config, opts *redis.UniversalOptions, logs *zap.Logger, tp trace.TracerProvider, mtr metric.MeterProvider,
 ) (redis.UniversalClient, error) {
-	ruc := redis.NewUniversalClient(opts)
+	ruc := newClient(opts)
+	ruc.AddHook(NewLogHook(cfg, logs.Named("client")))
 	if tp != nil {
 		if err := redisotel.InstrumentTracing(ruc, redisotel.WithTracerProvider(tp)); err != nil {
 			return nil, fmt.Errorf("failed to instrument with tracing: %w", err)
@@ -101,7 +213,7 @@ func Provide() fx.Option {
 		fx.Provide(fx.Annotate(NewOptions)),
 		// Init the client and ping on start, close on shutdown
 		fx.Provide(fx.Annotate(New,
-			fx.ParamTags(``, ``, `optional:"true"`, `optional:"true"`),
+			fx.ParamTags(``, ``, ``, `optional:"true"`, `optional:"true"`),
 			fx.OnStart(func(ctx context.Context, red redis.UniversalClient) error {
 				if err := red.Ping(ctx).Err(); err != nil {
 					return fmt.Errorf("failed to ping redis: %w", err)
@@ -116,6 +228,11 @@ func Provide() fx.Option {
 
 				return nil
 			}))),
+		// provide higher-level primitives
+		fx.Provide(NewLocker, NewLimiter),
+		// provide the health checker
+		fx.Provide(NewHealthChecker),
+		clhealth.ProvideChecker(NewChecker),
 	)
 }
 
@@ -2,6 +2,15 @@ package clredis_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -16,6 +25,7 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 )
 
 func TestClredis(t *testing.T) {
@@ -77,3 +87,66 @@ var _ = Describe("redis observed", func() {
 		Expect(rm.ScopeMetrics[0].Scope.Name).To(Equal("github.com/redis/go-redis/extra/redisotel"))
 	})
 })
+
+var _ = Describe("options", func() {
+	It("should configure sentinel, routing and the pool", func() {
+		opts, err := clredis.NewOptions(clredis.Config{
+			Addrs:              []string{"s1:26379", "s2:26379"},
+			DB:                 2,
+			SentinelMasterName: "mymaster",
+			RouteByLatency:     true,
+			PoolSize:           20,
+			PoolTimeout:        time.Second,
+		}, zap.NewNop())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(opts.MasterName).To(Equal("mymaster"))
+		Expect(opts.DB).To(Equal(2))
+		Expect(opts.ReadOnly).To(BeTrue())
+		Expect(opts.RouteByLatency).To(BeTrue())
+		Expect(opts.PoolSize).To(Equal(20))
+		Expect(opts.PoolTimeout).To(Equal(time.Second))
+		Expect(opts.TLSConfig).To(BeNil())
+	})
+
+	It("should verify tls by default", func() {
+		opts, err := clredis.NewOptions(clredis.Config{EnableTLS: true}, zap.NewNop())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(opts.TLSConfig.InsecureSkipVerify).To(BeFalse())
+		Expect(opts.TLSConfig.RootCAs).To(BeNil())
+	})
+
+	It("should load the ca bundle", func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "test-ca"},
+			NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour), IsCA: true, BasicConstraintsValid: true,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+		Expect(err).ToNot(HaveOccurred())
+
+		file := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+		Expect(os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)).To(Succeed())
+
+		opts, err := clredis.NewOptions(clredis.Config{EnableTLS: true, TLSCAFile: file}, zap.NewNop())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(opts.TLSConfig.RootCAs).ToNot(BeNil())
+
+		Expect(os.WriteFile(file, []byte("not a cert"), 0o600)).To(Succeed())
+		_, err = clredis.NewOptions(clredis.Config{EnableTLS: true, TLSCAFile: file}, zap.NewNop())
+		Expect(err).To(MatchError(clredis.ErrNoCACertificates))
+	})
+
+	It("should use a failover cluster client when reading from replicas in sentinel mode", func() {
+		opts, err := clredis.NewOptions(clredis.Config{
+			Addrs: []string{"localhost:26379"}, SentinelMasterName: "mymaster", ReadFromReplicas: true,
+		}, zap.NewNop())
+		Expect(err).ToNot(HaveOccurred())
+
+		red, err := clredis.New(clredis.Config{}, opts, zap.NewNop(), nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(red.Close)
+		Expect(red).To(BeAssignableToTypeOf(&redis.ClusterClient{}))
+	})
+})
@@ -0,0 +1,366 @@
+package clredis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crewlinker/clgo/clzap"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	// payloadField is the stream message field that holds the json encoded payload.
+	payloadField = "payload"
+	// errorField is added to messages on the dead-letter stream.
+	errorField = "error"
+	// traceFieldPrefix prefixes the fields that carry the trace context.
+	traceFieldPrefix = "otel-"
+	// deadLetterSuffix is added to the stream name to form the dead-letter stream.
+	deadLetterSuffix = ":dead"
+	// streamHandlersGroup is the fx value group for stream handlers.
+	streamHandlersGroup = "clredis.stream_handlers"
+)
+
+// Message is a decoded stream message.
+type Message[T any] struct {
+	ID      string
+	Stream  string
+	Payload T
+}
+
+// StreamHandler handles the messages of a stream.
+type StreamHandler interface {
+	Stream() string
+	Handle(ctx context.Context, msg redis.XMessage) error
+}
+
+// typedHandler implements the StreamHandler by decoding the payload.
+type typedHandler[T any] struct {
+	stream string
+	fn     func(ctx context.Context, msg Message[T]) error
+}
+
+// NewStreamHandler inits a handler for the stream that decodes the payload into T.
+func NewStreamHandler[T any](stream string, fn func(ctx context.Context, msg Message[T]) error) StreamHandler {
+	return typedHandler[T]{stream: stream, fn: fn}
+}
+
+func (h typedHandler[T]) Stream() string { return h.stream }
+
+func (h typedHandler[T]) Handle(ctx context.Context, msg redis.XMessage) error {
+	data, _ := msg.Values[payloadField].(string)
+
+	var payload T
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		return fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	return h.fn(ctx, Message[T]{ID: msg.ID, Stream: h.stream, Payload: payload})
+}
+
+// Producer adds messages to streams.
+type Producer struct {
+	red  redis.UniversalClient
+	prop propagation.TextMapPropagator
+}
+
+// NewProducer inits a producer, the propagator is optional and defaults to the global propagator.
+func NewProducer(red redis.UniversalClient, prop propagation.TextMapPropagator) *Producer {
+	if prop == nil {
+		prop = otel.GetTextMapPropagator()
+	}
+
+	return &Producer{red: red, prop: prop}
+}
+
+// Publish adds the json encoded payload to the stream, along with the trace context of ctx.
+func (p *Producer) Publish(ctx context.Context, stream string, payload any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	carrier := propagation.MapCarrier{}
+	p.prop.Inject(ctx, carrier)
+
+	values := map[string]any{payloadField: string(data)}
+	for k, v := range carrier {
+		values[traceFieldPrefix+k] = v
+	}
+
+	id, err := p.red.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: values}).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to add to stream: %w", err)
+	}
+
+	return id, nil
+}
+
+// Consumer reads streams in a consumer group and dispatches the messages to the handlers. Messages that fail are
+// retried after they have been pending for some time, and moved to a dead-letter stream when they keep failing.
+type Consumer struct {
+	cfg      Config
+	logs     *zap.Logger
+	red      redis.UniversalClient
+	prop     propagation.TextMapPropagator
+	tracer   trace.Tracer
+	handlers []StreamHandler
+	consumer string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewConsumer inits the consumer. The tracer provider and propagator are optional.
+func NewConsumer(
+	cfg Config,
+	logs *zap.Logger,
+	red redis.UniversalClient,
+	handlers []StreamHandler,
+	tp trace.TracerProvider,
+	prop propagation.TextMapPropagator,
+) *Consumer {
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+
+	if prop == nil {
+		prop = otel.GetTextMapPropagator()
+	}
+
+	consumer := cfg.StreamConsumer
+	if consumer == "" {
+		host, _ := os.Hostname()
+		consumer = host + "-" + strconv.Itoa(os.Getpid())
+	}
+
+	return &Consumer{
+		cfg:      cfg,
+		logs:     logs.Named("consumer"),
+		red:      red,
+		prop:     prop,
+		tracer:   tp.Tracer("github.com/crewlinker/clgo/clredis"),
+		handlers: handlers,
+		consumer: consumer,
+	}
+}
+
+// Start creates the consumer groups and starts reading the streams.
+func (c *Consumer) Start(ctx context.Context) error {
+	for _, h := range c.handlers {
+		if err := c.red.XGroupCreateMkStream(ctx, h.Stream(), c.cfg.StreamGroup, "$").Err(); err != nil &&
+			!strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return fmt.Errorf("failed to create consumer group for stream '%s': %w", h.Stream(), err)
+		}
+	}
+
+	// the loops run until stopped, they are not bound to the start context.
+	lctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	for _, h := range c.handlers {
+		c.wg.Add(1)
+
+		go c.loop(lctx, h)
+	}
+
+	return nil
+}
+
+// Stop stops reading the streams and waits for messages that are being handled, or until ctx is done.
+func (c *Consumer) Stop(ctx context.Context) error {
+	if c.cancel == nil {
+		return nil
+	}
+
+	c.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain consumer: %w", ctx.Err())
+	}
+}
+
+// loop reads new messages for the handler, and claims stale ones periodically.
+func (c *Consumer) loop(ctx context.Context, h StreamHandler) {
+	defer c.wg.Done()
+
+	lastClaim := time.Now()
+
+	for ctx.Err() == nil {
+		if time.Since(lastClaim) >= c.cfg.StreamClaimInterval {
+			c.claim(ctx, h)
+			lastClaim = time.Now()
+		}
+
+		streams, err := c.red.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.cfg.StreamGroup,
+			Consumer: c.consumer,
+			Streams:  []string{h.Stream(), ">"},
+			Count:    c.cfg.StreamBatchSize,
+			Block:    c.cfg.StreamBlock,
+		}).Result()
+
+		switch {
+		case errors.Is(err, redis.Nil), ctx.Err() != nil:
+			continue
+		case err != nil:
+			c.logs.Error("failed to read from stream, backing off", zap.String("stream", h.Stream()), zap.Error(err))
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(c.cfg.StreamBlock):
+			}
+
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				c.process(h, msg)
+			}
+		}
+	}
+}
+
+// claim messages that have been pending for too long, because the handler failed or the consumer died.
+func (c *Consumer) claim(ctx context.Context, h StreamHandler) {
+	start := "0-0"
+
+	for {
+		msgs, next, err := c.red.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   h.Stream(),
+			Group:    c.cfg.StreamGroup,
+			Consumer: c.consumer,
+			MinIdle:  c.cfg.StreamClaimMinIdle,
+			Start:    start,
+			Count:    c.cfg.StreamBatchSize,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				c.logs.Error("failed to auto-claim pending messages", zap.String("stream", h.Stream()), zap.Error(err))
+			}
+
+			return
+		}
+
+		for _, msg := range msgs {
+			c.process(h, msg)
+		}
+
+		if next == "0-0" || len(msgs) < 1 {
+			return
+		}
+
+		start = next
+	}
+}
+
+// process a single message. It is not bound to the loop's context so handling is not interrupted by a stop.
+func (c *Consumer) process(h StreamHandler, msg redis.XMessage) {
+	carrier := propagation.MapCarrier{}
+
+	for k, v := range msg.Values {
+		if s, ok := v.(string); ok && strings.HasPrefix(k, traceFieldPrefix) {
+			carrier[strings.TrimPrefix(k, traceFieldPrefix)] = s
+		}
+	}
+
+	ctx, span := c.tracer.Start(c.prop.Extract(context.Background(), carrier), h.Stream()+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "redis"),
+			attribute.String("messaging.destination.name", h.Stream()),
+			attribute.String("messaging.message.id", msg.ID)))
+	defer span.End()
+
+	logs := clzap.Log(ctx, c.logs).With(zap.String("stream", h.Stream()), zap.String("message_id", msg.ID))
+
+	herr := h.Handle(ctx, msg)
+	if herr == nil {
+		if err := c.red.XAck(ctx, h.Stream(), c.cfg.StreamGroup, msg.ID).Err(); err != nil {
+			logs.Error("failed to ack message", zap.Error(err))
+		}
+
+		return
+	}
+
+	span.RecordError(herr)
+	span.SetStatus(codes.Error, herr.Error())
+
+	pending, err := c.red.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: h.Stream(), Group: c.cfg.StreamGroup, Start: msg.ID, End: msg.ID, Count: 1,
+	}).Result()
+	if err != nil || len(pending) < 1 {
+		logs.Error("failed to handle message, and to determine its retry count", zap.Error(herr), zap.Error(err))
+
+		return
+	}
+
+	if pending[0].RetryCount < c.cfg.StreamMaxRetries {
+		logs.Warn("failed to handle message, will retry",
+			zap.Error(herr), zap.Int64("retry_count", pending[0].RetryCount))
+
+		return
+	}
+
+	logs.Error("failed to handle message, moving to dead-letter stream",
+		zap.Error(herr), zap.Int64("retry_count", pending[0].RetryCount))
+
+	values := map[string]any{errorField: herr.Error()}
+	for k, v := range msg.Values {
+		values[k] = v
+	}
+
+	if err := c.red.XAdd(ctx, &redis.XAddArgs{Stream: h.Stream() + deadLetterSuffix, Values: values}).Err(); err != nil {
+		logs.Error("failed to add message to dead-letter stream", zap.Error(err))
+
+		return
+	}
+
+	if err := c.red.XAck(ctx, h.Stream(), c.cfg.StreamGroup, msg.ID).Err(); err != nil {
+		logs.Error("failed to ack dead-lettered message", zap.Error(err))
+	}
+}
+
+// ProvideStreamHandler provides a stream handler to the consumer, the constructor must return a StreamHandler.
+func ProvideStreamHandler(constructor any) fx.Option {
+	return fx.Provide(fx.Annotate(constructor, fx.ResultTags(`group:"`+streamHandlersGroup+`"`)))
+}
+
+// ProvideConsumer provides a consumer for the stream handlers, and a producer for publishing messages.
+func ProvideConsumer() fx.Option {
+	return fx.Options(
+		fx.Provide(fx.Annotate(NewProducer, fx.ParamTags(``, `optional:"true"`))),
+		fx.Provide(fx.Annotate(NewConsumer,
+			fx.ParamTags(``, ``, ``, `group:"`+streamHandlersGroup+`"`, `optional:"true"`, `optional:"true"`),
+			fx.OnStart(func(ctx context.Context, c *Consumer) error { return c.Start(ctx) }),
+			fx.OnStop(func(ctx context.Context, c *Consumer) error { return c.Stop(ctx) }),
+		)),
+		// force the consumer to be constructed so its lifecycle hooks run.
+		fx.Invoke(func(*Consumer) {}),
+	)
+}
@@ -0,0 +1,156 @@
+package clredis_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/crewlinker/clgo/clredis"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+)
+
+type orderPlaced struct {
+	OrderID string `json:"order_id"`
+}
+
+var _ = Describe("stream consumer", func() {
+	var mr *miniredis.Miniredis
+	var app *fx.App
+	var prod *clredis.Producer
+	var red redis.UniversalClient
+	var exp *tracetest.InMemoryExporter
+	var tp *sdktrace.TracerProvider
+	var handled chan clredis.Message[orderPlaced]
+	var fails atomic.Int64
+
+	BeforeEach(func(ctx context.Context) {
+		mr = miniredis.NewMiniRedis()
+		Expect(mr.Start()).To(Succeed())
+		DeferCleanup(mr.Close)
+
+		exp = tracetest.NewInMemoryExporter()
+		tp = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+		handled = make(chan clredis.Message[orderPlaced], 10)
+		fails.Store(0)
+
+		app = fx.New(fx.Populate(&prod, &red),
+			ProvideMini(mr, func(c *clredis.Config) {
+				c.StreamConsumer = "c1"
+				c.StreamBlock = time.Millisecond * 50
+				c.StreamClaimInterval = time.Millisecond * 20
+				c.StreamClaimMinIdle = time.Millisecond * 10
+				c.StreamMaxRetries = 3
+			}),
+			fx.Supply(fx.Annotate(tp, fx.As(new(trace.TracerProvider)))),
+			fx.Supply(fx.Annotate(propagation.TraceContext{}, fx.As(new(propagation.TextMapPropagator)))),
+			clredis.ProvideConsumer(),
+			clredis.ProvideStreamHandler(func() clredis.StreamHandler {
+				return clredis.NewStreamHandler("orders", func(
+					ctx context.Context, msg clredis.Message[orderPlaced],
+				) error {
+					if msg.Payload.OrderID == "bad" {
+						fails.Add(1)
+
+						return errors.New("always fails") //nolint:goerr113
+					}
+
+					if msg.Payload.OrderID == "flaky" && fails.Add(1) < 2 {
+						return errors.New("fails once") //nolint:goerr113
+					}
+
+					handled <- msg
+
+					return nil
+				})
+			}))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should create the group on start", func(ctx context.Context) {
+		groups, err := red.XInfoGroups(ctx, "orders").Result()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(groups).To(HaveLen(1))
+		Expect(groups[0].Name).To(Equal("default"))
+	})
+
+	It("should handle and ack with the trace context propagated", func(ctx context.Context) {
+		ctx, span := tp.Tracer("test").Start(ctx, "publish")
+		id, err := prod.Publish(ctx, "orders", orderPlaced{OrderID: "o1"})
+		span.End()
+		Expect(err).ToNot(HaveOccurred())
+
+		var msg clredis.Message[orderPlaced]
+		Eventually(handled).Should(Receive(&msg))
+		Expect(msg.ID).To(Equal(id))
+		Expect(msg.Stream).To(Equal("orders"))
+		Expect(msg.Payload.OrderID).To(Equal("o1"))
+
+		Eventually(func() int64 {
+			pending, _ := red.XPending(ctx, "orders", "default").Result()
+
+			return pending.Count
+		}).Should(BeZero())
+
+		var process tracetest.SpanStub
+		Eventually(func() bool {
+			for _, s := range exp.GetSpans() {
+				if s.Name == "orders process" {
+					process = s
+
+					return true
+				}
+			}
+
+			return false
+		}).Should(BeTrue())
+		Expect(process.Parent.TraceID()).To(Equal(span.SpanContext().TraceID()))
+		Expect(process.Parent.SpanID()).To(Equal(span.SpanContext().SpanID()))
+	})
+
+	It("should retry a failing message by claiming it", func(ctx context.Context) {
+		_, err := prod.Publish(ctx, "orders", orderPlaced{OrderID: "flaky"})
+		Expect(err).ToNot(HaveOccurred())
+
+		var msg clredis.Message[orderPlaced]
+		Eventually(handled).Should(Receive(&msg))
+		Expect(msg.Payload.OrderID).To(Equal("flaky"))
+		Expect(fails.Load()).To(Equal(int64(2)))
+	})
+
+	It("should move to the dead-letter stream after max retries", func(ctx context.Context) {
+		id, err := prod.Publish(ctx, "orders", orderPlaced{OrderID: "bad"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Eventually(func() int64 { return red.XLen(ctx, "orders:dead").Val() }).Should(Equal(int64(1)))
+		Expect(fails.Load()).To(Equal(int64(3)))
+
+		dead, err := red.XRange(ctx, "orders:dead", "-", "+").Result()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dead[0].Values).To(HaveKeyWithValue("error", "always fails"))
+		Expect(dead[0].Values).To(HaveKeyWithValue("payload", `{"order_id":"bad"}`))
+
+		pending, err := red.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: "orders", Group: "default", Start: id, End: id, Count: 1,
+		}).Result()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(BeEmpty())
+	})
+
+	It("should drain on stop", func(ctx context.Context) {
+		Expect(app.Stop(ctx)).To(Succeed())
+
+		_, err := prod.Publish(ctx, "orders", orderPlaced{OrderID: "o2"})
+		Expect(err).To(HaveOccurred())
+		Consistently(handled).ShouldNot(Receive())
+	})
+})
@@ -0,0 +1,126 @@
+package clsentry
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/crewlinker/clgo/clzap"
+	sentry "github.com/getsentry/sentry-go"
+	"go.uber.org/zap/zapcore"
+)
+
+// Core turns log entries into Sentry events, with the fields as extras. An error field is reported as the
+// exception, with its stack trace.
+type Core struct {
+	zapcore.LevelEnabler
+	hub    *sentry.Hub
+	fields []zapcore.Field
+	flush  time.Duration
+}
+
+// NewCore inits a core that captures the entries of the level with the hub. If an entry has the context as a field
+// (see clzap.Context) and the context has a hub, such as the one of the request, that hub is used instead.
+func NewCore(hub *sentry.Hub, lvl zapcore.LevelEnabler, flush time.Duration) *Core {
+	return &Core{LevelEnabler: lvl, hub: hub, flush: flush}
+}
+
+// With adds the fields to the extras of the events.
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	return &Core{
+		LevelEnabler: c.LevelEnabler,
+		hub:          c.hub,
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+		flush:        c.flush,
+	}
+}
+
+// Check adds the core if the level is enabled.
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+// Write captures the entry as an event. Entries that are marked as handled (see clzap.Handled) are skipped, such
+// as the errors that clconnect logs but which are captured by the Interceptor.
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	fields = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+	if clzap.IsHandled(fields) {
+		return nil
+	}
+
+	hub, enc := c.hub, zapcore.NewMapObjectEncoder()
+
+	event := sentry.NewEvent()
+	event.Level, event.Message, event.Logger, event.Timestamp = sentryLevel(ent.Level), ent.Message, ent.LoggerName, ent.Time
+
+	for _, f := range fields {
+		switch v := f.Interface.(type) {
+		case context.Context:
+			if f.Type == zapcore.SkipType && sentry.HasHubOnContext(v) {
+				hub = sentry.GetHubFromContext(v)
+			}
+		case error:
+			if f.Type == zapcore.ErrorType {
+				event.Exception = append(event.Exception, newException(v))
+			}
+		}
+
+		f.AddTo(enc)
+	}
+
+	event.Extra = enc.Fields
+	if ent.Stack != "" {
+		event.Extra["stacktrace"] = ent.Stack
+	}
+
+	hub.CaptureEvent(event)
+
+	// the process is about to exit or panic, make sure the event is sent
+	if ent.Level > zapcore.ErrorLevel {
+		hub.Flush(c.flush)
+	}
+
+	return nil
+}
+
+// Sync flushes the events.
+func (c *Core) Sync() error {
+	c.hub.Flush(c.flush)
+
+	return nil
+}
+
+// newException reports the error as an exception, with the stack trace of the error or else of the caller.
+func newException(err error) sentry.Exception {
+	st := sentry.ExtractStacktrace(err)
+	if st == nil {
+		st = sentry.NewStacktrace()
+	}
+
+	return sentry.Exception{Type: reflect.TypeOf(err).String(), Value: err.Error(), Stacktrace: st}
+}
+
+// sentryLevel maps the zap level to the Sentry level.
+func sentryLevel(lvl zapcore.Level) sentry.Level {
+	switch {
+	case lvl <= zapcore.DebugLevel:
+		return sentry.LevelDebug
+	case lvl == zapcore.InfoLevel:
+		return sentry.LevelInfo
+	case lvl == zapcore.WarnLevel:
+		return sentry.LevelWarning
+	case lvl == zapcore.ErrorLevel:
+		return sentry.LevelError
+	default:
+		return sentry.LevelFatal
+	}
+}
+
+// newSecondaryCore provides the core as a secondary core for the logger.
+func newSecondaryCore(cfg Config, hub *sentry.Hub) *clzap.SecondaryCore {
+	return &clzap.SecondaryCore{Core: NewCore(hub, cfg.LogLevel, cfg.DefaultFlushTimeout), Name: moduleName}
+}
@@ -0,0 +1,140 @@
+package clsentry
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	sentry "github.com/getsentry/sentry-go"
+)
+
+// UserFunc returns the identity of the user that made the request, it can be provided to attach the user to the
+// events that are captured while handling the request.
+type UserFunc func(ctx context.Context) sentry.User
+
+// requestHub clones the hub for a request, the hub in the context is cloned if it has one.
+func requestHub(ctx context.Context, hub *sentry.Hub, user UserFunc) (context.Context, *sentry.Hub) {
+	if sentry.HasHubOnContext(ctx) {
+		hub = sentry.GetHubFromContext(ctx)
+	}
+
+	hub = hub.Clone()
+	if user != nil {
+		hub.Scope().SetUser(user(ctx))
+	}
+
+	return sentry.SetHubOnContext(ctx, hub), hub
+}
+
+// Interceptor captures panics, and errors with an internal or unknown code, of RPCs. Each RPC has its own hub
+// with the procedure and the user. Panics are only observed when the interceptor is wrapped by the recoverer.
+type Interceptor struct {
+	hub  *sentry.Hub
+	user UserFunc
+}
+
+// NewInterceptor inits the interceptor, the user func is optional.
+func NewInterceptor(hub *sentry.Hub, user UserFunc) *Interceptor {
+	return &Interceptor{hub: hub, user: user}
+}
+
+// WrapUnary captures for unary RPCs.
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (resp connect.AnyResponse, err error) {
+		ctx, hub := i.rpcHub(ctx, req.Spec(), req.Peer(), req.HTTPMethod())
+		defer capturePanic(ctx, hub)
+
+		resp, err = next(ctx, req)
+		captureError(hub, err)
+
+		return resp, err
+	})
+}
+
+// WrapStreamingClient doesn't capture, the interceptor is for handlers.
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler captures for streaming RPCs.
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(ctx context.Context, conn connect.StreamingHandlerConn) (err error) {
+		ctx, hub := i.rpcHub(ctx, conn.Spec(), conn.Peer(), "")
+		defer capturePanic(ctx, hub)
+
+		err = next(ctx, conn)
+		captureError(hub, err)
+
+		return err
+	})
+}
+
+// rpcHub clones the hub for the RPC, with the procedure.
+func (i *Interceptor) rpcHub(
+	ctx context.Context, spec connect.Spec, peer connect.Peer, method string,
+) (context.Context, *sentry.Hub) {
+	ctx, hub := requestHub(ctx, i.hub, i.user)
+	hub.Scope().SetTag("rpc.procedure", spec.Procedure)
+	hub.Scope().SetContext("rpc", sentry.Context{
+		"procedure":    spec.Procedure,
+		"protocol":     peer.Protocol,
+		"http_method":  method,
+		"peer_address": peer.Addr,
+	})
+
+	return ctx, hub
+}
+
+// capturePanic captures the panic, and panics again so it is still handled by the recoverer. It must be deferred.
+func capturePanic(ctx context.Context, hub *sentry.Hub) {
+	if v := recover(); v != nil {
+		hub.RecoverWithContext(ctx, v)
+		panic(v)
+	}
+}
+
+// captureError captures the error if it has an internal or unknown code, other codes are expected to be
+// handled by the client.
+func captureError(hub *sentry.Hub, err error) {
+	if err == nil {
+		return
+	}
+
+	switch connect.CodeOf(err) { //nolint:exhaustive
+	case connect.CodeInternal, connect.CodeUnknown:
+		hub.CaptureException(err)
+	}
+}
+
+// Middleware captures panics of http handlers. Each request has its own hub with the request and the user. It
+// should wrap the handlers after authentication, so the user can be determined.
+type Middleware struct {
+	hub  *sentry.Hub
+	user UserFunc
+}
+
+// NewMiddleware inits the middleware, the user func is optional.
+func NewMiddleware(hub *sentry.Hub, user UserFunc) *Middleware {
+	return &Middleware{hub: hub, user: user}
+}
+
+// Wrap the handler.
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, hub := requestHub(r.Context(), m.hub, m.user)
+		hub.Scope().SetRequest(r)
+
+		// capture the panic, and panic again so it is still handled by the server. Aborting isn't a failure.
+		defer func() {
+			if v := recover(); v != nil {
+				if v != http.ErrAbortHandler { //nolint:errorlint
+					hub.RecoverWithContext(ctx, v)
+				}
+
+				panic(v)
+			}
+		}()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,230 @@
+package clsentry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"connectrpc.com/connect"
+	clconnectv1 "github.com/crewlinker/clgo/clconnect/v1"
+	"github.com/crewlinker/clgo/clconnect/v1/clconnectv1connect"
+	"github.com/crewlinker/clgo/clconnect"
+	"github.com/crewlinker/clgo/clsentry"
+	"github.com/crewlinker/clgo/clzap"
+	sentry "github.com/getsentry/sentry-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// readWrite implements the read-write rpc for testing, it fails on request.
+type readWrite struct {
+	clconnectv1connect.UnimplementedReadWriteServiceHandler
+}
+
+func (readWrite) CheckHealth(
+	_ context.Context, req *connect.Request[clconnectv1.CheckHealthRequest],
+) (*connect.Response[clconnectv1.CheckHealthResponse], error) {
+	switch req.Msg.GetInduceError() {
+	case clconnectv1.InducedError_INDUCED_ERROR_PANIC:
+		panic("induced panic")
+	case clconnectv1.InducedError_INDUCED_ERROR_UNKNOWN:
+		return nil, errors.New("induced error")
+	case clconnectv1.InducedError_INDUCED_ERROR_UNSPECIFIED:
+		fallthrough
+	default:
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("not found"))
+	}
+}
+
+// newRPC provides the read-only and read-write rpc for testing.
+func newRPC() (
+	clconnectv1connect.ReadOnlyServiceHandler,
+	clconnect.ConstructHandler[clconnectv1connect.ReadOnlyServiceHandler],
+	clconnect.ConstructClient[clconnectv1connect.ReadOnlyServiceClient],
+	clconnectv1connect.ReadWriteServiceHandler,
+	clconnect.ConstructHandler[clconnectv1connect.ReadWriteServiceHandler],
+	clconnect.ConstructClient[clconnectv1connect.ReadWriteServiceClient],
+) {
+	return clconnectv1connect.UnimplementedReadOnlyServiceHandler{},
+		clconnectv1connect.NewReadOnlyServiceHandler,
+		clconnectv1connect.NewReadOnlyServiceClient,
+		readWrite{},
+		clconnectv1connect.NewReadWriteServiceHandler,
+		clconnectv1connect.NewReadWriteServiceClient
+}
+
+var _ = Describe("capture", func() {
+	var hub *sentry.Hub
+	var obs *clsentry.ObservedEvents
+	var logs *zap.Logger
+	var icp *clsentry.Interceptor
+	var mw *clsentry.Middleware
+
+	BeforeEach(func(ctx context.Context) {
+		app := fx.New(fx.Populate(&hub, &obs, &logs, &icp, &mw), Provide(make(chan string)),
+			fx.Supply(clsentry.UserFunc(func(context.Context) sentry.User { return sentry.User{ID: "user1"} })))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should capture error logs", func() {
+		logs.Named("some").Info("fine")
+		logs.Named("some").Error("failed", zap.Error(errors.New("boom")), zap.String("foo", "bar"))
+		hub.Flush(time.Second)
+
+		Expect(obs.Events()).To(HaveLen(1))
+
+		ev := obs.Events()[0]
+		Expect(ev.Message).To(Equal("failed"))
+		Expect(ev.Level).To(Equal(sentry.LevelError))
+		Expect(ev.Logger).To(Equal("some"))
+		Expect(ev.Extra).To(HaveKeyWithValue("foo", "bar"))
+		Expect(ev.Exception).To(HaveLen(1))
+		Expect(ev.Exception[0].Value).To(Equal("boom"))
+		Expect(ev.Exception[0].Stacktrace.Frames).ToNot(BeEmpty())
+	})
+
+	Describe("rpc", func() {
+		var rwc clconnectv1connect.ReadWriteServiceClient
+
+		BeforeEach(func(ctx context.Context) {
+			app := fx.New(fx.Populate(&hub, &obs, &rwc), Provide(make(chan string)),
+				fx.Supply(clsentry.UserFunc(func(context.Context) sentry.User { return sentry.User{ID: "user1"} })),
+				fx.Provide(newRPC),
+				clconnect.TestProvide[
+					clconnectv1connect.ReadOnlyServiceHandler,
+					clconnectv1connect.ReadWriteServiceHandler,
+					clconnectv1connect.ReadOnlyServiceClient,
+					clconnectv1connect.ReadWriteServiceClient,
+				]("clconnect"))
+			Expect(app.Start(ctx)).To(Succeed())
+			DeferCleanup(app.Stop)
+		})
+
+		check := func(ctx context.Context, induce clconnectv1.InducedError) {
+			_, err := rwc.CheckHealth(ctx, connect.NewRequest(&clconnectv1.CheckHealthRequest{
+				InduceError: induce, Echo: "foo",
+			}))
+			Expect(err).To(HaveOccurred())
+			hub.Flush(time.Second)
+		}
+
+		It("should capture unknown errors once, with the procedure and user", func(ctx context.Context) {
+			check(ctx, clconnectv1.InducedError_INDUCED_ERROR_UNKNOWN)
+
+			Expect(obs.Events()).To(HaveLen(1))
+
+			ev := obs.Events()[0]
+			Expect(ev.Exception[0].Value).To(Equal("induced error"))
+			Expect(ev.User.ID).To(Equal("user1"))
+			Expect(ev.Tags).To(HaveKeyWithValue("rpc.procedure", clconnectv1connect.ReadWriteServiceCheckHealthProcedure))
+		})
+
+		It("should capture panics once", func(ctx context.Context) {
+			check(ctx, clconnectv1.InducedError_INDUCED_ERROR_PANIC)
+
+			Expect(obs.Events()).To(HaveLen(1))
+			Expect(obs.Events()[0].Message).To(Equal("induced panic"))
+			Expect(obs.Events()[0].User.ID).To(Equal("user1"))
+		})
+
+		It("should not capture other errors", func(ctx context.Context) {
+			check(ctx, clconnectv1.InducedError_INDUCED_ERROR_UNSPECIFIED)
+			Expect(obs.Events()).To(BeEmpty())
+		})
+	})
+
+	Describe("streaming rpc", func() {
+		var client *connect.Client[emptypb.Empty, emptypb.Empty]
+
+		BeforeEach(func() {
+			hdl := connect.NewServerStreamHandler("/test.v1.Test/Stream",
+				func(_ context.Context, req *connect.Request[emptypb.Empty], _ *connect.ServerStream[emptypb.Empty]) error {
+					if req.Header().Get("X-Induce") == "panic" {
+						panic("induced panic")
+					}
+
+					return errors.New("induced error")
+				},
+				connect.WithRecover(func(context.Context, connect.Spec, http.Header, any) error {
+					return connect.NewError(connect.CodeInternal, errors.New("panicked"))
+				}),
+				connect.WithInterceptors(icp))
+
+			srv := httptest.NewServer(hdl)
+			DeferCleanup(srv.Close)
+
+			client = connect.NewClient[emptypb.Empty, emptypb.Empty](srv.Client(), srv.URL+"/test.v1.Test/Stream")
+		})
+
+		stream := func(ctx context.Context, induce string) {
+			req := connect.NewRequest(&emptypb.Empty{})
+			req.Header().Set("X-Induce", induce)
+
+			str, err := client.CallServerStream(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+
+			for str.Receive() {
+			}
+
+			Expect(str.Err()).To(HaveOccurred())
+			Expect(str.Close()).To(Succeed())
+			hub.Flush(time.Second)
+		}
+
+		It("should capture errors with the procedure and user", func(ctx context.Context) {
+			stream(ctx, "error")
+
+			Expect(obs.Events()).To(HaveLen(1))
+
+			ev := obs.Events()[0]
+			Expect(ev.Exception[0].Value).To(Equal("induced error"))
+			Expect(ev.User.ID).To(Equal("user1"))
+			Expect(ev.Tags).To(HaveKeyWithValue("rpc.procedure", "/test.v1.Test/Stream"))
+		})
+
+		It("should capture panics", func(ctx context.Context) {
+			stream(ctx, "panic")
+
+			Expect(obs.Events()).To(HaveLen(1))
+			Expect(obs.Events()[0].Message).To(Equal("induced panic"))
+		})
+	})
+
+	Describe("http", func() {
+		It("should capture panics with the request and user", func() {
+			hdl := mw.Wrap(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				logs.Error("failed", clzap.Context(r.Context()))
+				panic("induced panic")
+			}))
+
+			Expect(func() {
+				hdl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/some/path", nil))
+			}).To(PanicWith("induced panic"))
+			hub.Flush(time.Second)
+
+			Expect(obs.Events()).To(HaveLen(2))
+
+			for _, ev := range obs.Events() {
+				Expect(ev.User.ID).To(Equal("user1"))
+				Expect(ev.Request.URL).To(HaveSuffix("/some/path"))
+			}
+		})
+
+		It("should not capture aborts", func() {
+			hdl := mw.Wrap(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic(http.ErrAbortHandler) }))
+
+			Expect(func() {
+				hdl.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+			}).To(Panic())
+			hub.Flush(time.Second)
+
+			Expect(obs.Events()).To(BeEmpty())
+		})
+	})
+})
@@ -17,9 +17,11 @@ import (
 
 	"github.com/crewlinker/clgo/clbuildinfo"
 	"github.com/crewlinker/clgo/clconfig"
+	"github.com/crewlinker/clgo/clzap"
 	sentry "github.com/getsentry/sentry-go"
 	"github.com/samber/lo"
 	"go.uber.org/fx"
+	"go.uber.org/zap/zapcore"
 )
 
 // Config configures.
@@ -34,6 +36,8 @@ type Config struct {
 	AttachStacktrace bool `env:"ATTACH_STACKTRACE" envDefault:"true"`
 	// If set, will add this environment to the Sentry scope.
 	Environment string `env:"ENVIRONMENT" envDefault:"development"`
+	// LogLevel is the level at which log entries are captured as Sentry events.
+	LogLevel zapcore.Level `env:"LOG_LEVEL" envDefault:"error"`
 }
 
 // StringFromEventID converts a sentry event ID to a string pointer.
@@ -98,6 +102,13 @@ func Provide() fx.Option {
 			return nil
 		}))),
 
+		// capture the error logs as events
+		clzap.ProvideSecondaryCore(newSecondaryCore),
+
+		// provide the connect interceptor and http middleware that capture per request, with the (optional) user
+		fx.Provide(fx.Annotate(NewInterceptor, fx.ParamTags(``, `optional:"true"`))),
+		fx.Provide(fx.Annotate(NewMiddleware, fx.ParamTags(``, `optional:"true"`))),
+
 		// provide the environment configuration
 		clconfig.Provide[Config](strings.ToUpper(moduleName)+"_"),
 	)
@@ -0,0 +1,26 @@
+// Package cltenant defines how the tenant of a request is resolved, so packages that scope data to tenants
+// don't depend on the packages that authenticate the request.
+package cltenant
+
+import "context"
+
+// Tenant describes the tenant a request is scoped to.
+type Tenant struct {
+	// ID of the tenant, empty if the request is not scoped to a tenant.
+	ID string
+	// Role is the database role assumed for the tx. If empty the configured default role is used.
+	Role string
+}
+
+// Resolver resolves the tenant from the (authenticated) request context.
+type Resolver interface {
+	ResolveTenant(ctx context.Context) (Tenant, error)
+}
+
+// ResolverFunc implements the Resolver as a function.
+type ResolverFunc func(ctx context.Context) (Tenant, error)
+
+// ResolveTenant implements the Resolver interface.
+func (f ResolverFunc) ResolveTenant(ctx context.Context) (Tenant, error) {
+	return f(ctx)
+}
@@ -0,0 +1,184 @@
+package clwebserver
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// compress the response with brotli or gzip, depending on what the client accepts. Responses that are smaller
+// than minSize, already encoded or streamed as gRPC/Connect/event-stream are not compressed.
+func compress(minSize int, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enc := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if enc == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		cw := &compressWriter{ResponseWriter: w, enc: enc, minSize: minSize}
+		defer cw.Close()
+
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks the preferred encoding that the client accepts, brotli is preferred over gzip.
+func negotiateEncoding(accept string) string {
+	var gz, br bool
+
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "br":
+			br = true
+		case "gzip":
+			gz = true
+		}
+	}
+
+	switch {
+	case br:
+		return "br"
+	case gz:
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+// compressWriter buffers the start of the response to decide whether it should be compressed.
+type compressWriter struct {
+	http.ResponseWriter
+	enc     string
+	minSize int
+
+	status  int
+	buf     []byte
+	decided bool
+	encw    io.WriteCloser
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	// informational responses (e.g. 103 Early Hints) are sent before the final response, there can be several
+	if code < http.StatusOK {
+		w.ResponseWriter.WriteHeader(code)
+
+		return
+	}
+
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	if !w.decided {
+		if !w.compressible() {
+			w.decide(false)
+		} else {
+			w.buf = append(w.buf, b...)
+			if len(w.buf) >= w.minSize {
+				w.decide(true)
+			}
+
+			return len(b), nil
+		}
+	}
+
+	if w.encw != nil {
+		return w.encw.Write(b) //nolint:wrapcheck
+	}
+
+	return w.ResponseWriter.Write(b) //nolint:wrapcheck
+}
+
+// compressible returns whether the response can be compressed, based on its headers.
+func (w *compressWriter) compressible() bool {
+	hdr := w.Header()
+	ctype := hdr.Get("Content-Type")
+
+	return hdr.Get("Content-Encoding") == "" &&
+		w.status != http.StatusNoContent && w.status != http.StatusNotModified &&
+		!strings.HasPrefix(ctype, "application/grpc") && !strings.HasPrefix(ctype, "application/connect+") &&
+		!strings.HasPrefix(ctype, "text/event-stream")
+}
+
+// decide to compress or not, and write the header and the buffered start of the response.
+func (w *compressWriter) decide(compress bool) {
+	w.decided = true
+
+	if compress {
+		w.Header().Del("Content-Length")
+		w.Header().Set("Content-Encoding", w.enc)
+
+		if w.enc == "br" {
+			w.encw = brotli.NewWriterLevel(w.ResponseWriter, brotli.DefaultCompression)
+		} else {
+			w.encw = gzip.NewWriter(w.ResponseWriter)
+		}
+	}
+
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+
+	if len(w.buf) < 1 {
+		return
+	}
+
+	if w.encw != nil {
+		_, _ = w.encw.Write(w.buf)
+	} else {
+		_, _ = w.ResponseWriter.Write(w.buf)
+	}
+
+	w.buf = nil
+}
+
+// Flush implements http.Flusher, a response that is flushed before reaching the minimum size is compressed
+// only if it has any content.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(len(w.buf) > 0 && w.compressible())
+	}
+
+	if fl, ok := w.encw.(interface{ Flush() error }); ok {
+		_ = fl.Flush()
+	}
+
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Close writes what is buffered and finishes the compression.
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		w.decide(false)
+	}
+
+	if w.encw != nil {
+		return w.encw.Close() //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// Unwrap allows the http.ResponseController to access the underlying writer.
+func (w *compressWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
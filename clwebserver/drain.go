@@ -0,0 +1,94 @@
+package clwebserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// Readiness reports whether the server is ready to receive traffic. It fails as soon as the server starts
+// draining so the load balancer can deregister the target before connections are closed.
+type Readiness struct{ draining atomic.Bool }
+
+// NewReadiness inits the readiness.
+func NewReadiness() *Readiness { return &Readiness{} }
+
+// Ready returns whether the server is ready to receive traffic.
+func (r *Readiness) Ready() bool { return !r.draining.Load() }
+
+// StartDraining makes the readiness fail.
+func (r *Readiness) StartDraining() { r.draining.Store(true) }
+
+// ServeHTTP responds with 200 when ready, and 503 when draining.
+func (r *Readiness) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	if !r.Ready() {
+		http.Error(w, "draining", http.StatusServiceUnavailable)
+
+		return
+	}
+
+	fmt.Fprintf(w, "ready")
+}
+
+// DrainMetrics measures the connections of the server, and how long it takes to drain them.
+type DrainMetrics struct {
+	open      atomic.Int64
+	openCount metric.Int64UpDownCounter
+	drainTime metric.Float64Histogram
+	drainOpen metric.Int64Histogram
+	attrs     metric.MeasurementOption
+}
+
+// NewDrainMetrics inits the metrics, the meter provider is optional. The attributes are added to all measurements,
+// e.g. to tell named servers apart.
+func NewDrainMetrics(mp metric.MeterProvider, attrs ...attribute.KeyValue) (*DrainMetrics, error) {
+	if mp == nil {
+		mp = noop.NewMeterProvider()
+	}
+
+	mtr := mp.Meter("github.com/crewlinker/clgo/clwebserver")
+	dm := &DrainMetrics{attrs: metric.WithAttributes(attrs...)}
+
+	var err error
+	if dm.openCount, err = mtr.Int64UpDownCounter("http.server.open_connections",
+		metric.WithDescription("Number of open connections to the server.")); err != nil {
+		return nil, fmt.Errorf("failed to init open connections counter: %w", err)
+	}
+
+	if dm.drainTime, err = mtr.Float64Histogram("http.server.drain.duration", metric.WithUnit("s"),
+		metric.WithDescription("Time it took to drain the connections on shutdown.")); err != nil {
+		return nil, fmt.Errorf("failed to init drain duration histogram: %w", err)
+	}
+
+	if dm.drainOpen, err = mtr.Int64Histogram("http.server.drain.open_connections",
+		metric.WithDescription("Number of open connections when draining started.")); err != nil {
+		return nil, fmt.Errorf("failed to init drain connections histogram: %w", err)
+	}
+
+	return dm, nil
+}
+
+// connState keeps track of the open connections, it is used as the server's ConnState hook.
+func (dm *DrainMetrics) connState(_ net.Conn, state http.ConnState) {
+	switch state { //nolint:exhaustive
+	case http.StateNew:
+		dm.open.Add(1)
+		dm.openCount.Add(context.Background(), 1, dm.attrs)
+	case http.StateHijacked, http.StateClosed:
+		dm.open.Add(-1)
+		dm.openCount.Add(context.Background(), -1, dm.attrs)
+	}
+}
+
+// drained records the draining that started at the time with the number of open connections.
+func (dm *DrainMetrics) drained(ctx context.Context, started time.Time, open int64) {
+	dm.drainTime.Record(ctx, time.Since(started).Seconds(), dm.attrs)
+	dm.drainOpen.Record(ctx, open, dm.attrs)
+}
@@ -0,0 +1,98 @@
+package clwebserver_test
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/crewlinker/clgo/clwebserver"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/fx"
+	"golang.org/x/net/http2"
+)
+
+var _ = Describe("h2c and draining", func() {
+	var lnr net.Listener
+	var app *fx.App
+	var rdr *sdkmetric.ManualReader
+
+	BeforeEach(func(ctx context.Context) {
+		rdr = sdkmetric.NewManualReader()
+		app = fx.New(clwebserver.Provide(),
+			fx.Decorate(func(c clwebserver.Config) clwebserver.Config {
+				c.BindAddrPort = "127.0.0.1:0"
+				c.EnableH2C = true
+				c.ReadinessPath = "/readyz"
+				c.ShutdownDelay = time.Millisecond * 300
+
+				return c
+			}),
+			fx.Invoke(func(s *http.Server) {}),
+			fx.Populate(&lnr),
+			fx.Supply(fx.Annotate(sdkmetric.NewMeterProvider(sdkmetric.WithReader(rdr)),
+				fx.As(new(metric.MeterProvider)))),
+			fx.Supply(fx.Annotate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Proto", r.Proto)
+			}), fx.As(new(http.Handler)))),
+			clzap.TestProvide())
+		Expect(app.Start(ctx)).To(Succeed())
+	})
+
+	It("should serve http2 without tls", func() {
+		client := &http.Client{Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, addr)
+			},
+		}}
+
+		resp, err := client.Get("http://" + lnr.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Header.Get("X-Proto")).To(Equal("HTTP/2.0"))
+		resp.Body.Close()
+
+		resp, err = http.Get("http://" + lnr.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Header.Get("X-Proto")).To(Equal("HTTP/1.1"))
+		resp.Body.Close()
+
+		Expect(app.Stop(context.Background())).To(Succeed())
+	})
+
+	It("should fail readiness before shutting down, and measure draining", func(ctx context.Context) {
+		Expect(http.Get("http://" + lnr.Addr().String() + "/readyz")).To(HaveHTTPStatus(http.StatusOK))
+
+		stopped := make(chan error)
+		go func() { stopped <- app.Stop(context.Background()) }()
+
+		Eventually(func() int {
+			resp, err := http.Get("http://" + lnr.Addr().String() + "/readyz")
+			if err != nil {
+				return 0
+			}
+			defer resp.Body.Close()
+
+			return resp.StatusCode
+		}).Should(Equal(http.StatusServiceUnavailable))
+
+		Eventually(stopped).Should(Receive(BeNil()))
+
+		var rm metricdata.ResourceMetrics
+		Expect(rdr.Collect(ctx, &rm)).To(Succeed())
+
+		names := []string{}
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			names = append(names, m.Name)
+		}
+
+		Expect(names).To(ContainElements("http.server.open_connections", "http.server.drain.duration",
+			"http.server.drain.open_connections"))
+	})
+})
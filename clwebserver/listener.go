@@ -0,0 +1,316 @@
+package clwebserver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// InheritEnv is the environment variable that lists the bind addresses of the listeners that a process inherits
+// from its parent during an upgrade. The listeners are passed as file descriptors, starting at 3, in that order.
+const InheritEnv = "CLWEBSERVER_INHERITED_LISTENERS"
+
+// ErrNoSystemdSocket is returned when a systemd socket is configured but not passed to the process.
+var ErrNoSystemdSocket = errors.New("clwebserver: no socket passed by systemd")
+
+// listenFdsStart is the first file descriptor that is passed by systemd, or by the parent during an upgrade.
+const listenFdsStart = 3
+
+// NewListener provides a tcp connection listener for the webserver. It doesn't support unix sockets, systemd
+// sockets or listeners that are inherited during an upgrade, use NewNetListener for those.
+func NewListener(cfg Config) (*net.TCPListener, error) {
+	return listenTCP(cfg.BindAddrPort)
+}
+
+// NewNetListener provides a connection listener for the webserver. The bind address is a tcp address, a unix socket
+// path prefixed with "unix:" or a socket passed by systemd prefixed with "systemd:". If the process inherited a
+// listener for the bind address from its parent (during an upgrade) that listener is used instead.
+func NewNetListener(cfg Config) (net.Listener, error) {
+	ln, err := inheritedListener(cfg.BindAddrPort)
+	if err != nil {
+		return nil, err
+	}
+
+	if ln == nil {
+		switch {
+		case strings.HasPrefix(cfg.BindAddrPort, "unix:"):
+			ln, err = listenUnix(cfg, strings.TrimPrefix(cfg.BindAddrPort, "unix:"))
+		case strings.HasPrefix(cfg.BindAddrPort, "systemd:"):
+			ln, err = listenSystemd(strings.TrimPrefix(cfg.BindAddrPort, "systemd:"))
+		default:
+			ln, err = listenTCP(cfg.BindAddrPort)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return active.track(cfg.BindAddrPort, ln), nil
+}
+
+// listenTCP listens on the tcp address.
+func listenTCP(bind string) (*net.TCPListener, error) {
+	ap, err := netip.ParseAddrPort(bind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse addr/port: %w", err)
+	}
+
+	ln, err := net.ListenTCP("tcp", net.TCPAddrFromAddrPort(ap))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	return ln, nil
+}
+
+// listenUnix listens on the unix socket at the path, and sets its mode and ownership. A stale socket file that is
+// left behind by a process that crashed is removed.
+func listenUnix(cfg Config, path string) (net.Listener, error) {
+	mode, err := strconv.ParseUint(cfg.UnixSocketMode, 8, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse unix socket mode: %w", err)
+	}
+
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+
+			return nil, fmt.Errorf("failed to listen: unix socket is in use: %s", path) //nolint:goerr113
+		}
+
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale unix socket: %w", err)
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	if err := os.Chmod(path, os.FileMode(mode)); err != nil {
+		ln.Close()
+
+		return nil, fmt.Errorf("failed to change unix socket mode: %w", err)
+	}
+
+	if cfg.UnixSocketUser == "" && cfg.UnixSocketGroup == "" {
+		return ln, nil
+	}
+
+	uid, gid, err := lookupOwner(cfg.UnixSocketUser, cfg.UnixSocketGroup)
+	if err == nil {
+		err = os.Chown(path, uid, gid)
+	}
+
+	if err != nil {
+		ln.Close()
+
+		return nil, fmt.Errorf("failed to change unix socket owner: %w", err)
+	}
+
+	return ln, nil
+}
+
+// lookupOwner resolves the user and group names (or ids), empty values resolve to -1 which leaves them unchanged.
+func lookupOwner(usr, grp string) (uid, gid int, err error) {
+	uid, gid = -1, -1
+
+	if usr != "" {
+		if u, lerr := user.Lookup(usr); lerr == nil {
+			usr = u.Uid
+		}
+
+		if uid, err = strconv.Atoi(usr); err != nil {
+			return uid, gid, fmt.Errorf("failed to lookup user '%s': %w", usr, err)
+		}
+	}
+
+	if grp != "" {
+		if g, lerr := user.LookupGroup(grp); lerr == nil {
+			grp = g.Gid
+		}
+
+		if gid, err = strconv.Atoi(grp); err != nil {
+			return uid, gid, fmt.Errorf("failed to lookup group '%s': %w", grp, err)
+		}
+	}
+
+	return uid, gid, nil
+}
+
+// listenSystemd uses a socket passed by systemd socket activation, see sd_listen_fds(3). Without a name the first
+// socket is used, else the socket with the name in LISTEN_FDNAMES.
+func listenSystemd(name string) (net.Listener, error) {
+	nfds, names := systemdSockets()
+	if nfds < 1 {
+		return nil, ErrNoSystemdSocket
+	}
+
+	idx := 0
+	if name != "" {
+		idx = -1
+
+		for i, n := range names {
+			if n == name && i < nfds {
+				idx = i
+
+				break
+			}
+		}
+
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: with name '%s'", ErrNoSystemdSocket, name)
+		}
+	}
+
+	return fileListener(listenFdsStart+idx, "systemd:"+name)
+}
+
+// systemd holds the sockets that systemd passed to the process, read from the environment once.
+var systemd = struct {
+	sync.Once
+	nfds  int
+	names []string
+}{}
+
+// systemdSockets returns the number of sockets that systemd passed to the process, and their names. The environment
+// variables are unset after reading them so they are not inherited by processes that we start.
+func systemdSockets() (nfds int, names []string) {
+	systemd.Do(func() {
+		if os.Getenv("LISTEN_PID") == strconv.Itoa(os.Getpid()) {
+			systemd.nfds, _ = strconv.Atoi(os.Getenv("LISTEN_FDS"))
+			systemd.names = strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+		}
+
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	})
+
+	return systemd.nfds, systemd.names
+}
+
+// inheritedListener returns the listener that is inherited from the parent for the bind address, or nil.
+func inheritedListener(bind string) (net.Listener, error) {
+	for i, addr := range strings.Split(os.Getenv(InheritEnv), ",") {
+		if addr == bind {
+			return fileListener(listenFdsStart+i, bind)
+		}
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+// fileListener inits a listener from the file descriptor. The descriptor can only be used once.
+func fileListener(fd int, name string) (net.Listener, error) {
+	passed.Lock()
+	defer passed.Unlock()
+
+	if passed.used[fd] {
+		return nil, fmt.Errorf("failed to use passed socket: already used: %s", name) //nolint:goerr113
+	}
+
+	f := os.NewFile(uintptr(fd), name)
+	defer f.Close() // the listener uses a duplicate
+
+	ln, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init listener from passed socket: %w", err)
+	}
+
+	passed.used[fd] = true
+
+	return ln, nil
+}
+
+// passed keeps track of the passed file descriptors that are in use.
+var passed = struct {
+	sync.Mutex
+	used map[int]bool
+}{used: map[int]bool{}}
+
+// active keeps track of the listeners that are active, so they can be passed to a child process.
+var active = &listeners{byAddr: map[string]*trackedListener{}}
+
+// listeners that are active, by bind address.
+type listeners struct {
+	mu     sync.Mutex
+	byAddr map[string]*trackedListener
+}
+
+// track the listener until it is closed.
+func (ls *listeners) track(bind string, ln net.Listener) net.Listener {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	tl := &trackedListener{Listener: ln, bind: bind, ls: ls}
+	ls.byAddr[bind] = tl
+
+	return tl
+}
+
+// files returns duplicates of the active listeners' file descriptors, and their bind addresses. Unix sockets will
+// no longer be removed when they are closed, since the child process continues to use them.
+func (ls *listeners) files() (files []*os.File, binds []string, err error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	for bind, tl := range ls.byAddr {
+		fl, ok := tl.Listener.(interface{ File() (*os.File, error) })
+		if !ok {
+			return files, binds, fmt.Errorf("failed to pass listener, it has no file: %s", bind) //nolint:goerr113
+		}
+
+		f, err := fl.File()
+		if err != nil {
+			return files, binds, fmt.Errorf("failed to get listener file: %w", err)
+		}
+
+		if ul, ok := tl.Listener.(*net.UnixListener); ok {
+			ul.SetUnlinkOnClose(false)
+		}
+
+		files, binds = append(files, f), append(binds, bind)
+	}
+
+	return files, binds, nil
+}
+
+// unlinkOnClose makes the unix sockets to be removed again when they are closed, after they were not passed to a
+// child process after all.
+func (ls *listeners) unlinkOnClose() {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	for _, tl := range ls.byAddr {
+		if ul, ok := tl.Listener.(*net.UnixListener); ok {
+			ul.SetUnlinkOnClose(true)
+		}
+	}
+}
+
+// trackedListener removes itself from the active listeners when closed.
+type trackedListener struct {
+	net.Listener
+	bind string
+	ls   *listeners
+}
+
+// Close the listener.
+func (tl *trackedListener) Close() error {
+	tl.ls.mu.Lock()
+	if tl.ls.byAddr[tl.bind] == tl {
+		delete(tl.ls.byAddr, tl.bind)
+	}
+	tl.ls.mu.Unlock()
+
+	return tl.Listener.Close() //nolint:wrapcheck
+}
@@ -0,0 +1,218 @@
+package clwebserver_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/crewlinker/clgo/clwebserver"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+)
+
+// runChild serves "child" on the listener that is passed to the process, until it is killed.
+func runChild(mode string) {
+	if mode == "systemd" {
+		os.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid())) // systemd sets this after forking
+	}
+
+	if mode == "fail" {
+		os.Exit(1) // e.g. invalid configuration of the upgraded binary
+	}
+
+	ln, err := clwebserver.NewNetListener(clwebserver.Config{BindAddrPort: os.Getenv("CLWEBSERVER_TEST_CHILD_BIND")})
+	if err != nil {
+		panic(err)
+	}
+
+	if mode == "systemd" && os.Getenv("LISTEN_FDS") != "" {
+		panic("systemd environment was not unset")
+	}
+
+	if mode != "hang" {
+		if err := clwebserver.NotifyUpgradeReady(); err != nil {
+			panic(err)
+		}
+	}
+
+	go func() {
+		time.Sleep(time.Minute)
+		os.Exit(1)
+	}()
+
+	http.Serve(ln, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, "child") }))
+}
+
+// getBody performs a get request with the client and returns the body.
+func getBody(client *http.Client, url string) string {
+	resp, err := client.Get(url)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	return string(body)
+}
+
+// unixClient returns a http client that dials the unix socket.
+func unixClient(path string) *http.Client {
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+}
+
+var _ = Describe("listeners", func() {
+	var sock string
+
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "clws") // short path, unix socket paths are limited in length
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+
+		sock = filepath.Join(dir, "s.sock")
+	})
+
+	It("should listen on tcp", func() {
+		ln, err := clwebserver.NewListener(clwebserver.Config{BindAddrPort: "127.0.0.1:0"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ln.Close()).To(Succeed())
+	})
+
+	It("should listen on a unix socket with the mode", func() {
+		cfg := clwebserver.Config{BindAddrPort: "unix:" + sock, UnixSocketMode: "0600"}
+		ln, err := clwebserver.NewNetListener(cfg)
+		Expect(err).ToNot(HaveOccurred())
+
+		fi, err := os.Stat(sock)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fi.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+
+		_, err = clwebserver.NewNetListener(cfg)
+		Expect(err).To(MatchError(ContainSubstring("in use")))
+
+		go http.Serve(ln, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, "ok") }))
+		Expect(getBody(unixClient(sock), "http://unix/")).To(Equal("ok"))
+
+		Expect(ln.Close()).To(Succeed())
+		_, err = os.Stat(sock)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("should remove a stale unix socket", func() {
+		ul, err := net.ListenUnix("unix", &net.UnixAddr{Name: sock, Net: "unix"})
+		Expect(err).ToNot(HaveOccurred())
+		ul.SetUnlinkOnClose(false)
+		Expect(ul.Close()).To(Succeed())
+
+		ln, err := clwebserver.NewNetListener(clwebserver.Config{BindAddrPort: "unix:" + sock, UnixSocketMode: "0660"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ln.Close()).To(Succeed())
+	})
+
+	It("should fail without a systemd socket", func() {
+		_, err := clwebserver.NewNetListener(clwebserver.Config{BindAddrPort: "systemd:"})
+		Expect(err).To(MatchError(clwebserver.ErrNoSystemdSocket))
+	})
+
+	It("should use a socket passed by systemd", func() {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(ln.Close)
+
+		f, err := ln.(*net.TCPListener).File()
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(f.Close)
+
+		cmd := exec.Command(os.Args[0]) //nolint:gosec
+		cmd.Env = append(os.Environ(), "CLWEBSERVER_TEST_CHILD=systemd", "CLWEBSERVER_TEST_CHILD_BIND=systemd:web",
+			"LISTEN_FDS=2", "LISTEN_FDNAMES=web:other")
+		cmd.ExtraFiles = []*os.File{f}
+		Expect(cmd.Start()).To(Succeed())
+		DeferCleanup(cmd.Process.Kill)
+
+		Eventually(func() string { return getBody(http.DefaultClient, "http://"+ln.Addr().String()) }).
+			Should(Equal("child"))
+	})
+
+	startParent := func(ctx context.Context) (*fx.App, *clwebserver.Upgrader) {
+		var upg *clwebserver.Upgrader
+		var lnr net.Listener
+		app := fx.New(clwebserver.Provide(),
+			fx.Decorate(func(c clwebserver.Config) clwebserver.Config {
+				c.BindAddrPort = "unix:" + sock
+				c.UpgradeReadyTimeout = time.Second
+
+				return c
+			}),
+			fx.Populate(&upg, &lnr),
+			fx.Invoke(func(s *http.Server) {}),
+			fx.Supply(fx.Annotate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, "parent")
+			}), fx.As(new(http.Handler)))),
+			clzap.TestProvide(),
+			clwebserver.ProvideUpgrader(os.Interrupt))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop) // stops listening for the signal, if the spec didn't stop the app already
+
+		return app, upg
+	}
+
+	upgrade := func(upg *clwebserver.Upgrader, mode string) (*os.Process, error) {
+		os.Setenv("CLWEBSERVER_TEST_CHILD", mode)
+		os.Setenv("CLWEBSERVER_TEST_CHILD_BIND", "unix:"+sock)
+		defer os.Unsetenv("CLWEBSERVER_TEST_CHILD")
+		defer os.Unsetenv("CLWEBSERVER_TEST_CHILD_BIND")
+
+		return upg.Upgrade()
+	}
+
+	DescribeTable("should keep serving when the child doesn't become ready", func(ctx context.Context, mode string) {
+		app, upg := startParent(ctx)
+
+		_, err := upgrade(upg, mode)
+		Expect(err).To(MatchError(clwebserver.ErrChildNotReady))
+		Consistently(app.Done(), "100ms").ShouldNot(Receive())
+
+		client := unixClient(sock)
+		Expect(getBody(client, "http://unix/")).To(Equal("parent"))
+
+		Expect(app.Stop(ctx)).To(Succeed())
+
+		_, err = os.Stat(sock)
+		Expect(os.IsNotExist(err)).To(BeTrue()) // the socket is removed again, it was not passed on
+	},
+		Entry("when it fails", "fail"),
+		Entry("when it times out", "hang"),
+	)
+
+	It("should upgrade by passing the listeners to a child process", func(ctx context.Context) {
+		app, upg := startParent(ctx)
+
+		client := unixClient(sock)
+		Expect(getBody(client, "http://unix/")).To(Equal("parent"))
+
+		proc, err := upgrade(upg, "upgrade")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(proc.Kill)
+
+		Eventually(app.Done()).Should(Receive())
+		Expect(app.Stop(ctx)).To(Succeed())
+
+		Eventually(func() string { return getBody(client, "http://unix/") }).Should(Equal("child"))
+
+		_, err = os.Stat(sock)
+		Expect(err).ToNot(HaveOccurred()) // the parent must not remove the socket the child uses
+	})
+})
@@ -0,0 +1,335 @@
+package clwebserver
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crewlinker/clgo/clzap"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader is the header that holds the request id, it is read from the request and set on the response.
+const RequestIDHeader = "X-Request-Id"
+
+// to scope context keys.
+type ctxKey string
+
+// ClientIP returns the client ip of the request, as determined by the middleware.
+func ClientIP(ctx context.Context) netip.Addr {
+	addr, _ := ctx.Value(ctxKey("client_ip")).(netip.Addr)
+
+	return addr
+}
+
+// RequestID returns the id of the request, as determined by the middleware.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey("request_id")).(string)
+
+	return id
+}
+
+// ErrCORSWildcardWithCredentials is returned when any origin is allowed together with credentials, which would
+// allow any site to make credentialed requests.
+var ErrCORSWildcardWithCredentials = errors.New("clwebserver: cors allows any origin together with credentials")
+
+// Middleware wraps the server's handler with the standard middleware.
+type Middleware struct {
+	cfg  Config
+	logs *zap.Logger
+	tp   trace.TracerProvider
+	prop propagation.TextMapPropagator
+	mp   metric.MeterProvider
+}
+
+// NewMiddleware inits the middleware. The tracer provider, propagator and meter provider are optional.
+func NewMiddleware(
+	cfg Config,
+	logs *zap.Logger,
+	tp trace.TracerProvider,
+	prop propagation.TextMapPropagator,
+	mp metric.MeterProvider,
+) (*Middleware, error) {
+	if cfg.CORSAllowCredentials && slices.Contains(cfg.CORSAllowedOrigins, "*") {
+		return nil, ErrCORSWildcardWithCredentials
+	}
+
+	return &Middleware{cfg: cfg, logs: logs.Named("middleware"), tp: tp, prop: prop, mp: mp}, nil
+}
+
+// Wrap the handler with the middleware. From outer to inner: client ip extraction, request id and logger injection,
+// tracing, access logging, panic recovery, cors, body size limit and compression.
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	if !m.cfg.DisableCompression {
+		next = compress(m.cfg.CompressionMinBytes, next)
+	}
+
+	if m.cfg.MaxRequestBodyBytes > 0 {
+		next = limitBody(m.cfg.MaxRequestBodyBytes, next)
+	}
+
+	if len(m.cfg.CORSAllowedOrigins) > 0 {
+		next = m.cors(next)
+	}
+
+	next = m.recover(next)
+
+	if !m.cfg.DisableAccessLog {
+		next = m.accessLog(next)
+	}
+
+	oopts := []otelhttp.Option{}
+	if m.tp != nil {
+		oopts = append(oopts, otelhttp.WithTracerProvider(m.tp))
+	}
+
+	if m.prop != nil {
+		oopts = append(oopts, otelhttp.WithPropagators(m.prop))
+	}
+
+	if m.mp != nil {
+		oopts = append(oopts, otelhttp.WithMeterProvider(m.mp))
+	}
+
+	next = otelhttp.NewHandler(next, "http.server", append(oopts,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string { return r.Method + " " + r.URL.Path }))...)
+
+	return m.clientIP(m.requestLogger(next))
+}
+
+// clientIP determines the ip of the client. Behind (trusted) proxies, such as the ALB, it is the right-most address
+// in the X-Forwarded-For header that is not a trusted proxy.
+func (m *Middleware) clientIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var addr netip.Addr
+		if ap, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+			addr = ap.Addr().Unmap()
+		}
+
+		if m.trusted(addr) {
+			hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+			for i := len(hops) - 1; i >= 0; i-- {
+				hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+				if err != nil {
+					break // don't trust anything left of a malformed hop
+				}
+
+				if addr = hop.Unmap(); !m.trusted(addr) {
+					break
+				}
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey("client_ip"), addr)))
+	})
+}
+
+// trusted returns whether the address is a trusted proxy.
+func (m *Middleware) trusted(addr netip.Addr) bool {
+	for _, pfx := range m.cfg.TrustedProxies {
+		if addr.IsValid() && pfx.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requestLogger determines the request id and injects a logger for the request into the context.
+func (m *Middleware) requestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rid := r.Header.Get(RequestIDHeader)
+		if rid == "" || len(rid) > 128 { //nolint:gomnd
+			var rnd [16]byte
+			_, _ = rand.Read(rnd[:])
+			rid = hex.EncodeToString(rnd[:])
+		}
+
+		w.Header().Set(RequestIDHeader, rid)
+
+		ctx := context.WithValue(r.Context(), ctxKey("request_id"), rid)
+		ctx = clzap.WithLogger(ctx, m.logs.With(
+			zap.String("request_id", rid),
+			zap.Stringer("client_ip", ClientIP(ctx))))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// accessLog logs every request once it is served.
+func (m *Middleware) accessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, sw := time.Now(), &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+
+		clzap.Log(r.Context(), m.logs).Info("served request",
+			zap.String("method", r.Method),
+			zap.String("path", r.URL.Path),
+			zap.String("proto", r.Proto),
+			zap.Int("status", sw.Status()),
+			zap.Int64("bytes", sw.bytes),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("user_agent", r.UserAgent()))
+	})
+}
+
+// recover from panics in the handler, and respond with a 500 if nothing was written yet.
+func (m *Middleware) recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w}
+
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			if err, ok := rec.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(rec) // the server handles this on purpose, by aborting the response
+			}
+
+			clzap.Log(r.Context(), m.logs).Error("recovered from panic in handler",
+				zap.Any("panic", rec), zap.ByteString("stack", debug.Stack()))
+
+			if sw.status == 0 {
+				http.Error(sw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+		}()
+
+		next.ServeHTTP(sw, r)
+	})
+}
+
+// cors adds the cross-origin resource sharing headers, and responds to pre-flight requests. Origins that are allowed
+// explicitly are echoed back, other origins are allowed with a literal "*" if any origin is allowed, which browsers
+// never combine with credentials.
+func (m *Middleware) cors(next http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(m.cfg.CORSAllowedOrigins))
+	for _, o := range m.cfg.CORSAllowedOrigins {
+		allowed[o] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+
+		if !allowed[origin] && !allowed["*"] {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		if allowed[origin] {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
+
+		if m.cfg.CORSAllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if len(m.cfg.CORSExposedHeaders) > 0 {
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(m.cfg.CORSExposedHeaders, ", "))
+		}
+
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(m.cfg.CORSAllowedMethods, ", "))
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(m.cfg.CORSAllowedHeaders, ", "))
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(m.cfg.CORSMaxAge.Seconds())))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// limitBody limits the size of request bodies.
+func limitBody(limit int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// statusWriter records the status and number of bytes written.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// Status returns the status that was written, or 200 if nothing was written.
+func (w *statusWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+
+	return n, err //nolint:wrapcheck
+}
+
+// Flush implements http.Flusher for streaming responses.
+func (w *statusWriter) Flush() { _ = http.NewResponseController(w.ResponseWriter).Flush() }
+
+// Hijack implements http.Hijacker, e.g. for websockets.
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to hijack: %w", err)
+	}
+
+	return conn, brw, nil
+}
+
+// Unwrap allows the http.ResponseController to access the underlying writer.
+func (w *statusWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
@@ -0,0 +1,231 @@
+package clwebserver_test
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/netip"
+	"net/textproto"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/crewlinker/clgo/clwebserver"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var _ = Describe("middleware", func() {
+	var cfg clwebserver.Config
+	var obs *observer.ObservedLogs
+	var logs *zap.Logger
+
+	BeforeEach(func() {
+		cfg = clwebserver.Config{
+			MaxRequestBodyBytes: 10,
+			CompressionMinBytes: 100,
+			TrustedProxies:      []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+			CORSAllowedOrigins:  []string{"https://app.example.com"},
+			CORSAllowedMethods:  []string{"GET", "POST"},
+			CORSAllowedHeaders:  []string{"Content-Type"},
+			CORSExposedHeaders:  []string{"X-Request-Id"},
+		}
+
+		var core zapcore.Core
+		core, obs = observer.New(zap.DebugLevel)
+		logs = zap.New(core)
+	})
+
+	serve := func(h http.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mw, err := clwebserver.NewMiddleware(cfg, logs, nil, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		mw.Wrap(h).ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	It("should determine the client ip behind trusted proxies", func() {
+		var ips []netip.Addr
+		h := func(w http.ResponseWriter, r *http.Request) { ips = append(ips, clwebserver.ClientIP(r.Context())) }
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "10.0.1.1:1234"
+		req.Header.Set("X-Forwarded-For", "6.6.6.6, 1.2.3.4, 10.0.2.2")
+		serve(h, req)
+
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "5.5.5.5:1234"
+		req.Header.Set("X-Forwarded-For", "1.2.3.4")
+		serve(h, req)
+
+		Expect(ips).To(Equal([]netip.Addr{netip.MustParseAddr("1.2.3.4"), netip.MustParseAddr("5.5.5.5")}))
+	})
+
+	It("should inject a request logger and log access", func() {
+		rec := serve(func(w http.ResponseWriter, r *http.Request) {
+			clzap.Log(r.Context()).Info("in handler")
+			w.WriteHeader(http.StatusTeapot)
+		}, httptest.NewRequest(http.MethodGet, "/foo", nil))
+
+		Expect(rec.Code).To(Equal(http.StatusTeapot))
+		rid := rec.Header().Get(clwebserver.RequestIDHeader)
+		Expect(rid).To(HaveLen(32))
+
+		Expect(obs.FilterMessage("in handler").FilterField(zap.String("request_id", rid)).Len()).To(Equal(1))
+
+		access := obs.FilterMessage("served request").All()
+		Expect(access).To(HaveLen(1))
+		Expect(access[0].ContextMap()).To(HaveKeyWithValue("status", int64(http.StatusTeapot)))
+		Expect(access[0].ContextMap()).To(HaveKeyWithValue("path", "/foo"))
+		Expect(access[0].ContextMap()).To(HaveKeyWithValue("request_id", rid))
+	})
+
+	It("should keep the request id of the request", func() {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(clwebserver.RequestIDHeader, "abc")
+
+		var rid string
+		rec := serve(func(w http.ResponseWriter, r *http.Request) { rid = clwebserver.RequestID(r.Context()) }, req)
+		Expect(rid).To(Equal("abc"))
+		Expect(rec.Header().Get(clwebserver.RequestIDHeader)).To(Equal("abc"))
+	})
+
+	It("should recover from panics", func() {
+		rec := serve(func(w http.ResponseWriter, r *http.Request) { panic("oops") },
+			httptest.NewRequest(http.MethodGet, "/", nil))
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		Expect(obs.FilterMessage("recovered from panic in handler").Len()).To(Equal(1))
+		Expect(obs.FilterMessage("served request").All()[0].ContextMap()).To(HaveKeyWithValue("status", int64(500)))
+	})
+
+	It("should limit the request body", func() {
+		var rerr error
+		h := func(w http.ResponseWriter, r *http.Request) { _, rerr = io.ReadAll(r.Body) }
+
+		rec := serve(h, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("a", 11))))
+		Expect(rec.Code).To(Equal(http.StatusRequestEntityTooLarge))
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("a", 11)))
+		req.ContentLength = -1
+		serve(h, req)
+		Expect(rerr).To(MatchError(ContainSubstring("request body too large")))
+	})
+
+	It("should handle cors pre-flight requests", func() {
+		req := httptest.NewRequest(http.MethodOptions, "/", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+
+		rec := serve(func(w http.ResponseWriter, r *http.Request) {}, req)
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+		Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(Equal("https://app.example.com"))
+		Expect(rec.Header().Get("Access-Control-Allow-Methods")).To(Equal("GET, POST"))
+		Expect(rec.Header().Get("Access-Control-Max-Age")).To(Equal("0"))
+
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+		rec = serve(func(w http.ResponseWriter, r *http.Request) {}, req)
+		Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(BeEmpty())
+	})
+
+	It("should allow any origin without credentials", func() {
+		cfg.CORSAllowedOrigins = []string{"https://app.example.com", "*"}
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Origin", "https://other.example.com")
+		rec := serve(func(w http.ResponseWriter, r *http.Request) {}, req)
+		Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(Equal("*"))
+		Expect(rec.Header().Get("Access-Control-Allow-Credentials")).To(BeEmpty())
+
+		req.Header.Set("Origin", "https://app.example.com")
+		rec = serve(func(w http.ResponseWriter, r *http.Request) {}, req)
+		Expect(rec.Header().Get("Access-Control-Allow-Origin")).To(Equal("https://app.example.com"))
+	})
+
+	It("should reject any origin with credentials", func() {
+		cfg.CORSAllowedOrigins, cfg.CORSAllowCredentials = []string{"*"}, true
+
+		_, err := clwebserver.NewMiddleware(cfg, logs, nil, nil, nil)
+		Expect(err).To(MatchError(clwebserver.ErrCORSWildcardWithCredentials))
+	})
+
+	DescribeTable("compression", func(accept, body, expEnc string) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", accept)
+
+		rec := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			io.WriteString(w, body)
+		}, req)
+		Expect(rec.Header().Get("Content-Encoding")).To(Equal(expEnc))
+
+		var rd io.Reader = rec.Body
+		switch expEnc {
+		case "gzip":
+			gzr, err := gzip.NewReader(rec.Body)
+			Expect(err).ToNot(HaveOccurred())
+			rd = gzr
+		case "br":
+			rd = brotli.NewReader(rec.Body)
+		}
+
+		Expect(io.ReadAll(rd)).To(Equal([]byte(body)))
+	},
+		Entry("gzip", "gzip", strings.Repeat("a", 200), "gzip"),
+		Entry("prefer brotli", "gzip, br", strings.Repeat("a", 200), "br"),
+		Entry("brotli refused", "gzip, br;q=0", strings.Repeat("a", 200), "gzip"),
+		Entry("too small", "gzip, br", "small", ""),
+		Entry("not accepted", "", strings.Repeat("a", 200), ""),
+	)
+
+	It("should not compress connect streams", func() {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+
+		rec := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/connect+proto")
+			io.WriteString(w, strings.Repeat("a", 200))
+		}, req)
+		Expect(rec.Header().Get("Content-Encoding")).To(BeEmpty())
+	})
+
+	It("should pass informational responses through the compression", func(ctx context.Context) {
+		mw, err := clwebserver.NewMiddleware(cfg, logs, nil, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		srv := httptest.NewServer(mw.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Link", "</style.css>; rel=preload")
+			w.WriteHeader(http.StatusEarlyHints)
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, strings.Repeat("a", 200))
+		})))
+		DeferCleanup(srv.Close)
+
+		var infos []int
+		req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+			Got1xxResponse: func(code int, _ textproto.MIMEHeader) error {
+				infos = append(infos, code)
+
+				return nil
+			},
+		}), http.MethodGet, srv.URL, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		Expect(infos).To(Equal([]int{http.StatusEarlyHints}))
+		Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+		Expect(resp.Uncompressed).To(BeTrue()) // the transport asked for, and decoded, gzip
+		Expect(io.ReadAll(resp.Body)).To(Equal([]byte(strings.Repeat("a", 200))))
+	})
+})
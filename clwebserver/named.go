@@ -0,0 +1,152 @@
+package clwebserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+
+	"github.com/caarlos0/env/v10"
+	"github.com/crewlinker/clgo/clconfig"
+	"github.com/crewlinker/clgo/clhealth"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Route mounts a handler on a named server at the pattern, as understood by the http.ServeMux.
+type Route struct {
+	Pattern string
+	Handler http.Handler
+}
+
+// routeGroup returns the fx value group with the routes of the named server.
+func routeGroup(server string) string { return moduleName + "." + server }
+
+// NewMux inits a mux that serves the routes.
+func NewMux(routes []Route) http.Handler {
+	mux := http.NewServeMux()
+	for _, r := range routes {
+		mux.Handle(r.Pattern, r.Handler)
+	}
+
+	return mux
+}
+
+// ProvideNamed provides a named server with its own listener, configured from the environment with the
+// "CLWEBSERVER_<NAME>_" prefix. It serves the routes that are provided for it, and both the *http.Server and the
+// net.Listener are provided with the name tag. This allows keeping e.g. pprof and metrics off the public server. The
+// server is always started, and its metrics have the "server.name" attribute.
+func ProvideNamed(name string) fx.Option {
+	nameTag, groupTag := `name:"`+name+`"`, `group:"`+routeGroup(name)+`"`
+
+	return fx.Module(moduleName+"."+name,
+		// the incoming logger will be named after the module and the server
+		fx.Decorate(func(l *zap.Logger) *zap.Logger { return l.Named(moduleName).Named(name) }),
+		// provide the named server and listener. The components are not provided separately since they would
+		// conflict with those of other servers.
+		fx.Provide(fx.Annotate(named(name).new,
+			fx.ParamTags(`optional:"true"`, ``, ``, groupTag, `optional:"true"`, `optional:"true"`, `optional:"true"`),
+			fx.ResultTags(nameTag, nameTag))),
+		// force the server to be constructed, nothing else depends on it
+		fx.Invoke(fx.Annotate(func(*http.Server) {}, fx.ParamTags(nameTag))),
+	)
+}
+
+// named server, by its name.
+type named string
+
+// new inits the named server and its listener.
+func (name named) new(
+	envo env.Options,
+	logs *zap.Logger,
+	lc fx.Lifecycle,
+	routes []Route,
+	tp trace.TracerProvider,
+	prop propagation.TextMapPropagator,
+	mp metric.MeterProvider,
+) (*http.Server, net.Listener, error) {
+	cfg, err := clconfig.EnvConfigurer[Config](strings.ToUpper(moduleName+"_"+string(name)) + "_")(envo)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	dm, err := NewDrainMetrics(mp, attribute.String("server.name", string(name)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rdy := NewReadiness()
+
+	mw, err := NewMiddleware(cfg, logs, tp, prop, mp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	srv, err := New(cfg, logs, NewMux(routes), rdy, dm, mw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the listener is created last, so it is not left open when any of the above fails
+	ln, err := NewNetListener(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			serve(logs, ln, srv)
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return shutdown(ctx, cfg, logs, srv, rdy, dm)
+		},
+	})
+
+	return srv, ln, nil
+}
+
+// ProvideRoute provides a route for the named server, the constructor must return a Route.
+func ProvideRoute(server string, constructor any) fx.Option {
+	return fx.Provide(fx.Annotate(constructor, fx.ResultTags(`group:"`+routeGroup(server)+`"`)))
+}
+
+// ProvideHandler routes the pattern on the named server to a named http.Handler, such as the one provided by
+// clconnect.
+func ProvideHandler(server, pattern, handlerName string) fx.Option {
+	return fx.Provide(fx.Annotate(
+		func(h http.Handler) Route { return Route{Pattern: pattern, Handler: h} },
+		fx.ParamTags(`name:"`+handlerName+`"`),
+		fx.ResultTags(`group:"`+routeGroup(server)+`"`)))
+}
+
+// ProvideHealth routes the liveness and readiness of clhealth on the named server, at the configured paths.
+func ProvideHealth(server string) fx.Option {
+	return fx.Options(
+		ProvideRoute(server, func(cfg clhealth.Config, h *clhealth.Health) Route {
+			return Route{Pattern: cfg.LivePath, Handler: h.LiveHandler()}
+		}),
+		ProvideRoute(server, func(cfg clhealth.Config, h *clhealth.Health) Route {
+			return Route{Pattern: cfg.ReadyPath, Handler: h.ReadyHandler()}
+		}),
+	)
+}
+
+// ProvidePprof routes the pprof endpoints on "/debug/pprof/" of the named server.
+func ProvidePprof(server string) fx.Option {
+	return fx.Provide(fx.Annotate(func() []Route {
+		return []Route{
+			{Pattern: "/debug/pprof/", Handler: http.HandlerFunc(pprof.Index)},
+			{Pattern: "/debug/pprof/cmdline", Handler: http.HandlerFunc(pprof.Cmdline)},
+			{Pattern: "/debug/pprof/profile", Handler: http.HandlerFunc(pprof.Profile)},
+			{Pattern: "/debug/pprof/symbol", Handler: http.HandlerFunc(pprof.Symbol)},
+			{Pattern: "/debug/pprof/trace", Handler: http.HandlerFunc(pprof.Trace)},
+		}
+	}, fx.ResultTags(`group:"`+routeGroup(server)+`,flatten"`)))
+}
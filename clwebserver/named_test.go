@@ -0,0 +1,188 @@
+package clwebserver_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/crewlinker/clgo/clhealth"
+	"github.com/crewlinker/clgo/clwebserver"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/fx"
+)
+
+var _ = Describe("named servers", func() {
+	var public, admin net.Listener
+	var rdr *sdkmetric.ManualReader
+
+	BeforeEach(func(ctx context.Context) {
+		rdr = sdkmetric.NewManualReader()
+
+		for _, name := range []string{"PUBLIC", "ADMIN"} {
+			os.Setenv("CLWEBSERVER_"+name+"_BIND_ADDR_PORT", "127.0.0.1:0")
+			DeferCleanup(os.Unsetenv, "CLWEBSERVER_"+name+"_BIND_ADDR_PORT")
+		}
+
+		os.Setenv("CLWEBSERVER_ADMIN_READINESS_PATH", "/readyz")
+		DeferCleanup(os.Unsetenv, "CLWEBSERVER_ADMIN_READINESS_PATH")
+
+		app := fx.New(
+			clwebserver.Provide(),
+			fx.Decorate(func(c clwebserver.Config) clwebserver.Config {
+				c.BindAddrPort = "127.0.0.1:0"
+
+				return c
+			}),
+			fx.Supply(fx.Annotate(http.NotFoundHandler(), fx.As(new(http.Handler)))),
+			clwebserver.ProvideNamed("public"),
+			clwebserver.ProvideNamed("admin"),
+			fx.Provide(fx.Annotate(func() http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, "api") })
+			}, fx.ResultTags(`name:"api"`))),
+			clwebserver.ProvideHandler("public", "/", "api"),
+			clwebserver.ProvideRoute("admin", func() clwebserver.Route {
+				return clwebserver.Route{Pattern: "/metrics", Handler: http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, "metrics") })}
+			}),
+			clwebserver.ProvidePprof("admin"),
+			fx.Populate(
+				fx.Annotate(&public, fx.ParamTags(`name:"public"`)),
+				fx.Annotate(&admin, fx.ParamTags(`name:"admin"`))),
+			fx.Supply(fx.Annotate(sdkmetric.NewMeterProvider(sdkmetric.WithReader(rdr)),
+				fx.As(new(metric.MeterProvider)))),
+			clzap.TestProvide())
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	get := func(ln net.Listener, path string) (int, string) {
+		resp, err := http.Get("http://" + ln.Addr().String() + path)
+		Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		return resp.StatusCode, string(body)
+	}
+
+	It("should route to the servers separately", func() {
+		Expect(public.Addr().String()).ToNot(Equal(admin.Addr().String()))
+
+		code, body := get(public, "/foo")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("api"))
+
+		code, body = get(admin, "/metrics")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("metrics"))
+
+		code, _ = get(admin, "/debug/pprof/")
+		Expect(code).To(Equal(http.StatusOK))
+		code, _ = get(admin, "/readyz")
+		Expect(code).To(Equal(http.StatusOK))
+
+		code, _ = get(public, "/debug/pprof/")
+		Expect(code).To(Equal(http.StatusOK)) // the api handler serves everything on the public server
+		code, _ = get(admin, "/foo")
+		Expect(code).To(Equal(http.StatusNotFound))
+		code, _ = get(public, "/readyz")
+		Expect(code).To(Equal(http.StatusOK))
+	})
+
+	It("should measure the servers separately", func(ctx context.Context) {
+		get(public, "/foo")
+		get(admin, "/metrics")
+
+		var rm metricdata.ResourceMetrics
+		Expect(rdr.Collect(ctx, &rm)).To(Succeed())
+
+		servers := []string{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name != "http.server.open_connections" {
+					continue
+				}
+
+				for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+					name, _ := dp.Attributes.Value("server.name")
+					servers = append(servers, name.AsString())
+				}
+			}
+		}
+
+		Expect(servers).To(ConsistOf("public", "admin"))
+	})
+})
+
+var _ = Describe("named server errors", func() {
+	It("should not leave the listener open", func() {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		addr := ln.Addr().String()
+		Expect(ln.Close()).To(Succeed())
+
+		for k, v := range map[string]string{
+			"BIND_ADDR_PORT":         addr,
+			"CORS_ALLOWED_ORIGINS":   "*",
+			"CORS_ALLOW_CREDENTIALS": "true",
+		} {
+			os.Setenv("CLWEBSERVER_BROKEN_"+k, v)
+			DeferCleanup(os.Unsetenv, "CLWEBSERVER_BROKEN_"+k)
+		}
+
+		var srv *http.Server
+		app := fx.New(
+			clwebserver.ProvideNamed("broken"),
+			fx.Populate(fx.Annotate(&srv, fx.ParamTags(`name:"broken"`))),
+			clzap.TestProvide())
+		Expect(app.Err()).To(MatchError(clwebserver.ErrCORSWildcardWithCredentials))
+
+		ln, err = net.Listen("tcp", addr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ln.Close()).To(Succeed())
+	})
+})
+
+var _ = Describe("health routes", func() {
+	var admin net.Listener
+
+	BeforeEach(func(ctx context.Context) {
+		os.Setenv("CLWEBSERVER_ADMIN_BIND_ADDR_PORT", "127.0.0.1:0")
+		DeferCleanup(os.Unsetenv, "CLWEBSERVER_ADMIN_BIND_ADDR_PORT")
+
+		app := fx.New(
+			clhealth.Provide(),
+			clhealth.ProvideChecker(func() clhealth.Checker {
+				return clhealth.Checker{Name: "broken", Check: func(context.Context) error { return errors.New("broken") }}
+			}),
+			clwebserver.ProvideNamed("admin"),
+			clwebserver.ProvideHealth("admin"),
+			fx.Populate(fx.Annotate(&admin, fx.ParamTags(`name:"admin"`))),
+			clzap.TestProvide())
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should probe the liveness and readiness", func(ctx context.Context) {
+		code := 100
+		clhealth.CheckHealthAndExit(ctx, io.Discard,
+			[]string{"", "healthcheck", "http://" + admin.Addr().String() + "/livez", "200"}, func(i int) { code = i })
+		Expect(code).To(Equal(0))
+
+		var buf bytes.Buffer
+		clhealth.CheckHealthAndExit(ctx, &buf,
+			[]string{"", "healthcheck", "http://" + admin.Addr().String() + "/readyz", "200"}, func(i int) { code = i })
+		Expect(code).To(Equal(1))
+		Expect(buf.String()).To(ContainSubstring(`"error":"broken"`))
+	})
+})
@@ -3,6 +3,7 @@ package clwebserver
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
@@ -13,44 +14,112 @@ import (
 	"github.com/crewlinker/clgo/clconfig"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 // Config configures the http server.
 type Config struct {
-	// BindAddrPort configures where the web server will listen for incoming tcp traffic
+	// BindAddrPort configures where the web server will listen for incoming traffic: a tcp addr:port, a unix
+	// socket as "unix:/path/to.sock", or a socket passed by systemd as "systemd:" or "systemd:<name>"
 	BindAddrPort string `env:"BIND_ADDR_PORT" envDefault:"127.0.0.1:8282"`
+	// UnixSocketMode configures the file mode (in octal) of a unix socket
+	UnixSocketMode string `env:"UNIX_SOCKET_MODE" envDefault:"0660"`
+	// UnixSocketUser configures the owner (name or uid) of a unix socket, by default it is not changed
+	UnixSocketUser string `env:"UNIX_SOCKET_USER"`
+	// UnixSocketGroup configures the group (name or gid) of a unix socket, by default it is not changed
+	UnixSocketGroup string `env:"UNIX_SOCKET_GROUP"`
 	// HTTP read timeout, See: https://blog.cloudflare.com/exposing-go-on-the-internet/
 	ReadTimeout time.Duration `env:"READ_TIMEOUT" envDefault:"5s"`
 	// HTTP write timeout, See: https://blog.cloudflare.com/exposing-go-on-the-internet/
 	WriteTimeout time.Duration `env:"WRITE_TIMEOUT" envDefault:"12s"`
 	// HTTP idle timeout, See: https://blog.cloudflare.com/exposing-go-on-the-internet/
 	IdleTimeout time.Duration `env:"IDLE_TIMEOUT" envDefault:"120s"`
+	// MaxHeaderBytes limits the size of the request headers
+	MaxHeaderBytes int `env:"MAX_HEADER_BYTES" envDefault:"1048576"`
+	// EnableH2C serves HTTP/2 without TLS (h2c), e.g. for gRPC clients behind a TLS terminating load balancer
+	EnableH2C bool `env:"ENABLE_H2C" envDefault:"false"`
+	// TLSCertFile enables TLS (and HTTP/2 over TLS) with the certificate in the file
+	TLSCertFile string `env:"TLS_CERT_FILE"`
+	// TLSKeyFile is the file with the private key of the certificate
+	TLSKeyFile string `env:"TLS_KEY_FILE"`
+	// TLSReloadInterval configures how often the certificate files are checked for changes
+	TLSReloadInterval time.Duration `env:"TLS_RELOAD_INTERVAL" envDefault:"1m"`
+	// ReadinessPath serves the readiness of the server, it fails as soon as the server starts draining
+	ReadinessPath string `env:"READINESS_PATH"`
+	// ShutdownDelay is how long readiness fails before the server shuts down, this allows the load balancer to
+	// deregister the target before connections are closed
+	ShutdownDelay time.Duration `env:"SHUTDOWN_DELAY" envDefault:"0s"`
+	// UpgradeReadyTimeout is how long the upgrader waits for the upgraded child process to be ready
+	UpgradeReadyTimeout time.Duration `env:"UPGRADE_READY_TIMEOUT" envDefault:"30s"`
+
+	// DisableAccessLog disables logging of every request that is served
+	DisableAccessLog bool `env:"DISABLE_ACCESS_LOG" envDefault:"false"`
+	// MaxRequestBodyBytes limits the size of request bodies, zero disables the limit
+	MaxRequestBodyBytes int64 `env:"MAX_REQUEST_BODY_BYTES" envDefault:"10485760"`
+	// DisableCompression disables gzip/brotli compression of responses
+	DisableCompression bool `env:"DISABLE_COMPRESSION" envDefault:"false"`
+	// CompressionMinBytes is the minimum size of a response before it is compressed
+	CompressionMinBytes int `env:"COMPRESSION_MIN_BYTES" envDefault:"1024"`
+	// TrustedProxies are the CIDRs of proxies (e.g. the ALB's subnets) whose X-Forwarded-For header is trusted
+	TrustedProxies []netip.Prefix `env:"TRUSTED_PROXIES"`
+	// CORSAllowedOrigins enables CORS for the origins, "*" allows any origin
+	CORSAllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS"`
+	// CORSAllowedMethods configures the methods that are allowed cross-origin
+	CORSAllowedMethods []string `env:"CORS_ALLOWED_METHODS" envDefault:"GET,POST,PUT,PATCH,DELETE"`
+	// CORSAllowedHeaders configures the request headers that are allowed cross-origin
+	CORSAllowedHeaders []string `env:"CORS_ALLOWED_HEADERS" envDefault:"Content-Type,Authorization,X-Request-Id,Connect-Protocol-Version,Connect-Timeout-Ms"` //nolint:lll
+	// CORSExposedHeaders configures the response headers that are exposed cross-origin
+	CORSExposedHeaders []string `env:"CORS_EXPOSED_HEADERS" envDefault:"X-Request-Id"`
+	// CORSAllowCredentials allows cookies and authorization headers to be sent cross-origin
+	CORSAllowCredentials bool `env:"CORS_ALLOW_CREDENTIALS" envDefault:"false"`
+	// CORSMaxAge configures how long the result of a pre-flight request may be cached
+	CORSMaxAge time.Duration `env:"CORS_MAX_AGE" envDefault:"2h"`
 }
 
-// NewListener provides a tcp connection listener for the webserver.
-func NewListener(cfg Config) (*net.TCPListener, error) {
-	ap, err := netip.ParseAddrPort(cfg.BindAddrPort)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse addr/port: %w", err)
+// New inits the http server.
+func New(
+	cfg Config,
+	logs *zap.Logger,
+	h http.Handler,
+	rdy *Readiness,
+	dm *DrainMetrics,
+	mw *Middleware,
+) (*http.Server, error) {
+	h = mw.Wrap(h)
+
+	// readiness is served outside of the middleware, it would only clutter the logs and traces
+	if cfg.ReadinessPath != "" {
+		mux := http.NewServeMux()
+		mux.Handle(cfg.ReadinessPath, rdy)
+		mux.Handle("/", h)
+		h = mux
 	}
 
-	ln, err := net.ListenTCP("tcp", net.TCPAddrFromAddrPort(ap))
-	if err != nil {
-		return nil, fmt.Errorf("failed to listen: %w", err)
+	srv := &http.Server{
+		ReadTimeout:    cfg.ReadTimeout,
+		WriteTimeout:   cfg.WriteTimeout,
+		IdleTimeout:    cfg.IdleTimeout,
+		MaxHeaderBytes: cfg.MaxHeaderBytes,
+		Handler:        h,
+		ErrorLog:       zap.NewStdLog(logs),
+		ConnState:      dm.connState,
 	}
 
-	return ln, nil
-}
+	switch {
+	case cfg.TLSCertFile != "":
+		rl, err := NewCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSReloadInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init certificate reloader: %w", err)
+		}
 
-// New inits the http server.
-func New(cfg Config, logs *zap.Logger, h http.Handler, _ *net.TCPListener) *http.Server {
-	return &http.Server{
-		ReadTimeout:  cfg.ReadTimeout,
-		WriteTimeout: cfg.WriteTimeout,
-		IdleTimeout:  cfg.IdleTimeout,
-		Handler:      h,
-		ErrorLog:     zap.NewStdLog(logs),
+		// with tls, http2 is negotiated by the server itself
+		srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: rl.GetCertificate}
+	case cfg.EnableH2C:
+		srv.Handler = h2c.NewHandler(h, &http2.Server{IdleTimeout: cfg.IdleTimeout})
 	}
+
+	return srv, nil
 }
 
 // moduleName standardizes the module name.
@@ -62,27 +131,89 @@ func Provide() fx.Option {
 		// provide the config
 		clconfig.Provide[Config](strings.ToUpper(moduleName)+"_"),
 		// provide the listener
-		fx.Provide(fx.Annotate(NewListener)),
+		fx.Provide(NewNetListener),
 		// the incoming logger will be named after the module
 		fx.Decorate(func(l *zap.Logger) *zap.Logger { return l.Named(moduleName) }),
-		// provide the server dependency
-		fx.Provide(fx.Annotate(New,
-			fx.OnStart(func(ctx context.Context, logs *zap.Logger, ln *net.TCPListener, s *http.Server) error {
-				go s.Serve(ln) //nolint:errcheck
+		// provide the readiness, and the (optionally exported) draining metrics
+		fx.Provide(NewReadiness),
+		fx.Provide(fx.Annotate(NewDrainMetrics, fx.ParamTags(`optional:"true"`))),
+		// provide the standard middleware, telemetry is optional
+		fx.Provide(fx.Annotate(NewMiddleware,
+			fx.ParamTags(``, ``, `optional:"true"`, `optional:"true"`, `optional:"true"`))),
+		// provide the server dependency, served on the listener for the lifetime of the app
+		fx.Provide(newServer),
+	)
+}
 
-				logs.Info("http server started", zap.Stringer("addr", ln.Addr()))
+// newServer inits the server with New and serves it on the listener while the app is running.
+func newServer(
+	lc fx.Lifecycle,
+	cfg Config,
+	logs *zap.Logger,
+	h http.Handler,
+	ln net.Listener,
+	rdy *Readiness,
+	dm *DrainMetrics,
+	mw *Middleware,
+) (*http.Server, error) {
+	srv, err := New(cfg, logs, h, rdy, dm, mw)
+	if err != nil {
+		return nil, err
+	}
 
-				return nil
-			}),
-			fx.OnStop(func(ctx context.Context, logs *zap.Logger, s *http.Server) error {
-				dl, hasdl := ctx.Deadline()
-				logs.Info("shutting down http server", zap.Bool("has_dl", hasdl), zap.Duration("dl", time.Until(dl)))
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			serve(logs, ln, srv)
 
-				if err := s.Shutdown(ctx); err != nil {
-					return fmt.Errorf("failed to shut down: %w", err)
-				}
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return shutdown(ctx, cfg, logs, srv, rdy, dm)
+		},
+	})
 
-				return nil
-			}))),
-	)
+	return srv, nil
+}
+
+// serve starts serving on the listener in the background.
+func serve(logs *zap.Logger, ln net.Listener, s *http.Server) {
+	// note: serving modifies the tls config, so don't read it after starting
+	if s.TLSConfig != nil {
+		go s.ServeTLS(ln, "", "") //nolint:errcheck
+		logs.Info("https server started", zap.Stringer("addr", ln.Addr()))
+	} else {
+		go s.Serve(ln) //nolint:errcheck
+		logs.Info("http server started", zap.Stringer("addr", ln.Addr()))
+	}
+}
+
+// shutdown fails readiness, waits for the shutdown delay and then drains the server's connections.
+func shutdown(
+	ctx context.Context, cfg Config, logs *zap.Logger, s *http.Server, rdy *Readiness, dm *DrainMetrics,
+) error {
+	rdy.StartDraining()
+
+	// give the load balancer time to notice the failing readiness
+	if cfg.ShutdownDelay > 0 {
+		logs.Info("failing readiness before shutdown", zap.Duration("delay", cfg.ShutdownDelay))
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(cfg.ShutdownDelay):
+		}
+	}
+
+	dl, hasdl := ctx.Deadline()
+	started, open := time.Now(), dm.open.Load()
+	logs.Info("shutting down http server", zap.Bool("has_dl", hasdl), zap.Duration("dl", time.Until(dl)),
+		zap.Int64("open_connections", open))
+
+	err := s.Shutdown(ctx)
+	dm.drained(ctx, started, open)
+
+	if err != nil {
+		return fmt.Errorf("failed to shut down: %w", err)
+	}
+
+	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"testing"
 
 	"github.com/crewlinker/clgo/clwebserver"
@@ -15,13 +16,19 @@ import (
 )
 
 func TestWebserver(t *testing.T) {
+	if mode := os.Getenv("CLWEBSERVER_TEST_CHILD"); mode != "" {
+		runChild(mode) // the test binary is (re)executed as the child process by listener tests
+
+		return
+	}
+
 	t.Parallel()
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "clwebserver")
 }
 
 var _ = Describe("failed to handle webserver", func() {
-	var lnr *net.TCPListener
+	var lnr net.Listener
 	BeforeEach(func(ctx context.Context) {
 		app := fx.New(clwebserver.Provide(),
 			fx.Decorate(func(c clwebserver.Config) clwebserver.Config {
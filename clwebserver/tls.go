@@ -0,0 +1,86 @@
+package clwebserver
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertReloader loads the certificate from files and reloads it when the files change. This allows certificates to
+// be rotated without restarting the server.
+type CertReloader struct {
+	certFile, keyFile string
+	interval          time.Duration
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+	checked time.Time
+}
+
+// NewCertReloader inits the reloader and loads the certificate. The files are checked for changes at most
+// once every interval.
+func NewCertReloader(certFile, keyFile string, interval time.Duration) (*CertReloader, error) {
+	rl := &CertReloader{certFile: certFile, keyFile: keyFile, interval: interval}
+	if err := rl.reload(); err != nil {
+		return nil, err
+	}
+
+	return rl, nil
+}
+
+// GetCertificate can be used as the tls.Config's GetCertificate.
+func (rl *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if time.Since(rl.checked) < rl.interval {
+		return rl.cert, nil
+	}
+
+	// keep serving the last certificate if the new one is not (yet) valid, e.g. when half-way written.
+	_ = rl.reload()
+
+	return rl.cert, nil
+}
+
+// reload the certificate if the files were modified, must be called while holding the lock (or during init).
+func (rl *CertReloader) reload() error {
+	rl.checked = time.Now()
+
+	modTime, err := latestModTime(rl.certFile, rl.keyFile)
+	if err != nil {
+		return err
+	}
+
+	if rl.cert != nil && !modTime.After(rl.modTime) {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(rl.certFile, rl.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load key pair: %w", err)
+	}
+
+	rl.cert, rl.modTime = &cert, modTime
+
+	return nil
+}
+
+// latestModTime returns the latest modification time of the files.
+func latestModTime(files ...string) (latest time.Time, err error) {
+	for _, file := range files {
+		fi, err := os.Stat(file)
+		if err != nil {
+			return latest, fmt.Errorf("failed to stat: %w", err)
+		}
+
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,102 @@
+package clwebserver_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/crewlinker/clgo/clwebserver"
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+	"golang.org/x/net/http2"
+)
+
+// writeCert writes a self-signed certificate for 127.0.0.1 with the serial to the files.
+func writeCert(certFile, keyFile string, serial int64) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial), Subject: pkix.Name{CommonName: "test"},
+		NotBefore: time.Now().Add(-time.Minute), NotAfter: time.Now().Add(time.Hour),
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)).To(Succeed())
+	Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600)).
+		To(Succeed())
+}
+
+var _ = Describe("tls", func() {
+	var lnr net.Listener
+	var certFile, keyFile string
+
+	BeforeEach(func(ctx context.Context) {
+		dir := GinkgoT().TempDir()
+		certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+		writeCert(certFile, keyFile, 1)
+
+		app := fx.New(clwebserver.Provide(),
+			fx.Decorate(func(c clwebserver.Config) clwebserver.Config {
+				c.BindAddrPort = "127.0.0.1:0"
+				c.TLSCertFile, c.TLSKeyFile = certFile, keyFile
+				c.TLSReloadInterval = 0
+
+				return c
+			}),
+			fx.Invoke(func(s *http.Server) {}),
+			fx.Populate(&lnr),
+			fx.Supply(fx.Annotate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+				fx.As(new(http.Handler)))),
+			clzap.TestProvide())
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should serve http2 over tls and reload the certificate", func(ctx context.Context) {
+		client := &http.Client{Transport: &http2.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		}}
+
+		resp, err := client.Get("https://" + lnr.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.ProtoMajor).To(Equal(2))
+		Expect(resp.TLS.PeerCertificates[0].SerialNumber.Int64()).To(Equal(int64(1)))
+		resp.Body.Close()
+
+		writeCert(certFile, keyFile, 2)
+		future := time.Now().Add(time.Second)
+		Expect(os.Chtimes(certFile, future, future)).To(Succeed())
+		client.CloseIdleConnections()
+
+		resp, err = client.Get("https://" + lnr.Addr().String())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.TLS.PeerCertificates[0].SerialNumber.Int64()).To(Equal(int64(2)))
+		resp.Body.Close()
+	})
+})
+
+var _ = Describe("cert reloader", func() {
+	It("should fail to init without valid files", func() {
+		_, err := clwebserver.NewCertReloader("/no/such/cert.pem", "/no/such/key.pem", time.Minute)
+		Expect(err).To(MatchError(ContainSubstring("failed to stat")))
+	})
+})
@@ -0,0 +1,217 @@
+package clwebserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// UpgradeReadyEnv is the environment variable with the file descriptor that the child process writes to when it is
+// ready, during an upgrade. See NotifyUpgradeReady.
+const UpgradeReadyEnv = "CLWEBSERVER_UPGRADE_READY_FD"
+
+// ErrChildNotReady is returned when the child process exits, or times out, before it is ready.
+var ErrChildNotReady = errors.New("clwebserver: upgraded child process did not become ready")
+
+// Upgrader performs zero-downtime upgrades of the binary: it starts a new process that inherits the listeners, waits
+// for it to be ready and then shuts down this process gracefully. Both processes accept connections while this one
+// is draining. If the child doesn't become ready it is killed, and this process keeps serving.
+type Upgrader struct {
+	cfg  Config
+	logs *zap.Logger
+	sd   fx.Shutdowner
+
+	// command inits the command for the child process, it re-executes the (upgraded) binary by default.
+	command func() (*exec.Cmd, error)
+	sigs    chan os.Signal
+}
+
+// NewUpgrader inits the upgrader.
+func NewUpgrader(cfg Config, logs *zap.Logger, sd fx.Shutdowner) *Upgrader {
+	return &Upgrader{cfg: cfg, logs: logs.Named("upgrader"), sd: sd, command: func() (*exec.Cmd, error) {
+		exe, err := os.Executable()
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine executable: %w", err)
+		}
+
+		cmd := exec.Command(exe, os.Args[1:]...) //nolint:gosec
+		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+
+		return cmd, nil
+	}}
+}
+
+// Upgrade starts the child process with the listeners, waits for it to be ready and shuts down this process.
+func (u *Upgrader) Upgrade() (proc *os.Process, err error) {
+	files, binds, err := active.files()
+	defer func() {
+		for _, f := range files {
+			f.Close() // the child has its own copy
+		}
+
+		if err != nil {
+			active.unlinkOnClose() // this process keeps using the unix sockets
+		}
+	}()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := u.command()
+	if err != nil {
+		return nil, err
+	}
+
+	rdyr, rdyw, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create readiness pipe: %w", err)
+	}
+
+	defer rdyr.Close()
+
+	if err := u.start(cmd, files, binds, rdyw); err != nil {
+		return nil, err
+	}
+
+	if err := u.awaitReady(rdyr); err != nil {
+		cmd.Process.Kill() //nolint:errcheck
+		cmd.Wait()         //nolint:errcheck
+
+		return nil, err
+	}
+
+	u.logs.Info("upgraded child process is ready, shutting down", zap.Int("pid", cmd.Process.Pid),
+		zap.Strings("listeners", binds))
+
+	if err := u.sd.Shutdown(); err != nil {
+		return cmd.Process, fmt.Errorf("failed to shut down: %w", err)
+	}
+
+	return cmd.Process, nil
+}
+
+// start the child process with the listeners and the write end of the readiness pipe.
+func (u *Upgrader) start(cmd *exec.Cmd, files []*os.File, binds []string, rdyw *os.File) error {
+	defer rdyw.Close() // the child has its own copy, so reading ends when the child exits
+
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+
+	// systemd's variables only apply to this process, the child inherits the listeners through our own variable
+	env := make([]string, 0, len(cmd.Env)+2) //nolint:mnd
+	for _, kv := range cmd.Env {
+		if !strings.HasPrefix(kv, "LISTEN_") &&
+			!strings.HasPrefix(kv, InheritEnv+"=") &&
+			!strings.HasPrefix(kv, UpgradeReadyEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+
+	cmd.ExtraFiles = append(files[:len(files):len(files)], rdyw)
+	cmd.Env = append(env,
+		InheritEnv+"="+strings.Join(binds, ","),
+		UpgradeReadyEnv+"="+strconv.Itoa(listenFdsStart+len(files)))
+
+	err := cmd.Start()
+
+	// starting puts the (shared) file descriptions in blocking mode, our accepts would then no longer be interrupted
+	// by closing the listeners
+	for _, f := range files {
+		if nberr := syscall.SetNonblock(int(f.Fd()), true); nberr != nil {
+			return errors.Join(err, fmt.Errorf("failed to restore non-blocking listener: %w", nberr))
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to start child process: %w", err)
+	}
+
+	u.logs.Info("started upgraded child process, waiting for it to be ready", zap.Int("pid", cmd.Process.Pid),
+		zap.Duration("timeout", u.cfg.UpgradeReadyTimeout))
+
+	return nil
+}
+
+// awaitReady waits for the child to write to the readiness pipe, it fails when the child exits or times out.
+func (u *Upgrader) awaitReady(rdyr *os.File) error {
+	if err := rdyr.SetReadDeadline(time.Now().Add(u.cfg.UpgradeReadyTimeout)); err != nil {
+		return fmt.Errorf("failed to set readiness deadline: %w", err)
+	}
+
+	if _, err := rdyr.Read(make([]byte, 1)); err != nil {
+		return fmt.Errorf("%w: %w", ErrChildNotReady, err)
+	}
+
+	return nil
+}
+
+// NotifyUpgradeReady notifies the parent process that this process is ready, if it was started by an upgrade. The
+// upgrader does this when it is started, apps that upgrade without it should call it once they serve.
+func NotifyUpgradeReady() error {
+	fdv := os.Getenv(UpgradeReadyEnv)
+	if fdv == "" {
+		return nil
+	}
+
+	os.Unsetenv(UpgradeReadyEnv)
+
+	fd, err := strconv.Atoi(fdv)
+	if err != nil {
+		return fmt.Errorf("failed to parse readiness fd: %w", err)
+	}
+
+	f := os.NewFile(uintptr(fd), "upgrade-ready")
+	defer f.Close()
+
+	if _, err := f.Write([]byte{1}); err != nil {
+		return fmt.Errorf("failed to notify readiness: %w", err)
+	}
+
+	return nil
+}
+
+// ProvideUpgrader provides the upgrader, and performs an upgrade when the process receives the signal (e.g.
+// syscall.SIGUSR2). When it is started it notifies the parent process that it is ready (if it was upgraded), start
+// hooks run in order so it should be provided after the options that must be started before the parent stops.
+func ProvideUpgrader(sig os.Signal) fx.Option {
+	return fx.Options(
+		fx.Provide(fx.Annotate(NewUpgrader,
+			fx.OnStart(func(_ context.Context, u *Upgrader) error {
+				if err := NotifyUpgradeReady(); err != nil {
+					return err
+				}
+
+				u.sigs = make(chan os.Signal, 1)
+				signal.Notify(u.sigs, sig)
+
+				go func() {
+					for range u.sigs {
+						if _, err := u.Upgrade(); err != nil {
+							u.logs.Error("failed to upgrade, continue serving", zap.Error(err))
+						}
+					}
+				}()
+
+				return nil
+			}),
+			fx.OnStop(func(_ context.Context, u *Upgrader) {
+				signal.Stop(u.sigs)
+				close(u.sigs)
+			}),
+		)),
+		// force the upgrader to be constructed so it listens for the signal
+		fx.Invoke(func(*Upgrader) {}),
+	)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync/atomic"
 	"time"
 
 	"github.com/lestrrat-go/jwx/v2/jwk"
@@ -71,7 +72,8 @@ type Config struct {
 type Keys struct {
 	cfg    Config
 	workos struct {
-		public jwk.Set
+		public  jwk.Set
+		fetched atomic.Bool
 	}
 	signing struct {
 		private jwk.Set
@@ -133,5 +135,16 @@ func (keys *Keys) start(ctx context.Context) (err error) {
 		return fmt.Errorf("failed to fetch WorkOS public keys: %w", err)
 	}
 
+	keys.workos.fetched.Store(true)
+
+	return nil
+}
+
+// Check fails until the WorkOS public keys have been fetched.
+func (keys *Keys) Check(context.Context) error {
+	if !keys.workos.fetched.Load() {
+		return ErrKeysNotFetched
+	}
+
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/mitchellh/mapstructure"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // Impersonator describes who is impersonating the user (if any).
@@ -47,6 +48,15 @@ type Session struct {
 	RoleOverwrite string `mapstructure:"role_o"`
 }
 
+// MarshalLogObject logs the session without the refresh token, only whether it is present.
+func (s Session) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddBool("has_refresh_token", s.RefreshToken != "")
+	enc.AddString("org_id_o", s.OrganizationIDOverwrite)
+	enc.AddString("role_o", s.RoleOverwrite)
+
+	return nil
+}
+
 // BuildSessionToken builds an signed and encrypted session token.
 func (e Engine) BuildSessionToken(session Session) (string, error) {
 	encryptKey, ok := e.keys.encrypt.public.LookupKeyID(e.cfg.DefaultEncryptKeyID)
@@ -151,7 +161,7 @@ func (e Engine) identityFromAccessTokenAndSession(
 	}
 
 	if session != nil {
-		logs.Info("augmenting identity with session", zap.Any("session", session))
+		logs.Info("augmenting identity with session", zap.Object("session", session))
 
 		if session.OrganizationIDOverwrite != "" {
 			idn.OrganizationID = session.OrganizationIDOverwrite
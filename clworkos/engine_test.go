@@ -3,6 +3,7 @@ package clworkos_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -17,6 +18,7 @@ import (
 	"github.com/workos/workos-go/v4/pkg/usermanagement"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 var (
@@ -34,9 +36,10 @@ var _ = Describe("engine", func() {
 	var umm *clworkosmock.MockUserManagement
 	var orgm *clworkosmock.MockOrganizations
 	var logs *zap.Logger
+	var obs *observer.ObservedLogs
 	BeforeEach(func(ctx context.Context) {
 		app := fx.New(
-			fx.Populate(&engine, &umm, &orgm, &logs),
+			fx.Populate(&engine, &umm, &orgm, &logs, &obs),
 			Provide(WallTime06_46_08GMT)) // provide at a wall-clock where tokens have not expired
 		Expect(app.Start(ctx)).To(Succeed())
 		DeferCleanup(app.Stop)
@@ -248,6 +251,26 @@ var _ = Describe("engine", func() {
 
 			Expect(rec.Result().Cookies()).To(BeEmpty())
 		})
+
+		It("should not log the refresh token of the session", func(ctx context.Context) {
+			sessionToken := lo.Must(engine.BuildSessionToken(clworkos.Session{
+				RefreshToken: "some.refresh.token", RoleOverwrite: "foo",
+			}))
+
+			rec, req := httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil)
+			WithAccessToken(req, AccessToken1ValidFor06_46_09GMT)
+			WithSession(req, sessionToken)
+
+			_, err := engine.ContinueSession(ctx, logs, rec, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			entries := obs.FilterMessage("augmenting identity with session").All()
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].ContextMap()).To(HaveKeyWithValue("session", map[string]any{
+				"has_refresh_token": true, "org_id_o": "", "role_o": "foo",
+			}))
+			Expect(fmt.Sprint(entries[0].ContextMap())).NotTo(ContainSubstring("some.refresh.token"))
+		})
 	})
 
 	Describe("sign out", func() {
@@ -10,6 +10,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrKeysNotFetched is returned by the health check until the WorkOS public keys have been fetched.
+var ErrKeysNotFetched = errors.New("WorkOS public keys not fetched")
+
 // ErrRedirectToNotProvided is returned when the redirect_to query parameter is missing.
 var ErrRedirectToNotProvided = errors.New("missing redirect_to query parameter")
 
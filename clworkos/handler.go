@@ -12,6 +12,7 @@ import (
 
 	"github.com/advdv/bhttp"
 	"github.com/crewlinker/clgo/clconfig"
+	"github.com/crewlinker/clgo/clhealth"
 	"github.com/crewlinker/clgo/clworkos/clworkosmock"
 	"github.com/crewlinker/clgo/clzap"
 	"github.com/lestrrat-go/jwx/v2/jwt"
@@ -129,6 +130,10 @@ func Provide() fx.Option {
 		fx.Provide(fx.Annotate(NewEngine, fx.ParamTags(``, `optional:"true"`))),
 		// provide the keys
 		fx.Provide(fx.Annotate(NewKeys, fx.OnStart(func(ctx context.Context, k *Keys) error { return k.start(ctx) }))),
+		// register the health check of the keys
+		clhealth.ProvideChecker(func(k *Keys) clhealth.Checker {
+			return clhealth.Checker{Name: "workos_keys", Check: k.Check}
+		}),
 		// provide time.Now as the wall-clock time
 		fx.Supply(fx.Annotate(jwt.ClockFunc(time.Now), fx.As(new(Clock)))),
 		// provide the environment configuration
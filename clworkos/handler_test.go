@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/crewlinker/clgo/clhealth"
 	"github.com/crewlinker/clgo/clworkos"
 	"github.com/crewlinker/clgo/clworkos/clworkosmock"
 	"github.com/crewlinker/clgo/clzap"
@@ -173,3 +174,18 @@ func Provide(clockAt int64) fx.Option {
 		clzap.TestProvide(),
 	)
 }
+
+var _ = Describe("keys health", func() {
+	It("should not be ready until the public keys are fetched", func(ctx context.Context) {
+		var keys *clworkos.Keys
+		var hlth *clhealth.Health
+		app := fx.New(fx.Populate(&keys, &hlth), Provide(1715748368), clhealth.Provide())
+		Expect(keys.Check(ctx)).To(MatchError(clworkos.ErrKeysNotFetched))
+
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+
+		Expect(keys.Check(ctx)).To(Succeed())
+		Expect(hlth.Ready(ctx).Checks).To(HaveKeyWithValue("workos_keys", HaveField("Status", clhealth.StatusOK)))
+	})
+})
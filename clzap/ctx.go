@@ -3,6 +3,7 @@ package clzap
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"go.opentelemetry.io/otel/trace"
@@ -23,6 +24,33 @@ func LoggerFromContext(ctx context.Context) (*zap.Logger, bool) {
 // LambdaRequestIDKey determines the logging key for the AWS request id from the lambda context.
 var LambdaRequestIDKey = "requestId" //nolint:gochecknoglobals
 
+// TraceIDFormat determines how trace ids are formatted in the logs.
+type TraceIDFormat int32
+
+const (
+	// TraceIDFormatXRay formats trace ids as X-Ray does, e.g: "1-5759e988-bd862e3fe1be46a994272793". This allows
+	// CloudWatch to correlate the logs with the traces.
+	TraceIDFormatXRay TraceIDFormat = iota
+	// TraceIDFormatW3C formats trace ids as W3C trace context does, e.g: "5759e988bd862e3fe1be46a994272793".
+	TraceIDFormatW3C
+)
+
+// traceIDFormat holds the format of trace ids, it is set when the tracing is setup.
+var traceIDFormat atomic.Int32 //nolint:gochecknoglobals
+
+// SetTraceIDFormat sets how trace ids are formatted in the logs, it should match how traces are propagated.
+func SetTraceIDFormat(f TraceIDFormat) { traceIDFormat.Store(int32(f)) }
+
+// FormatTraceID formats the trace id in the format that is set.
+func FormatTraceID(tid trace.TraceID) string {
+	s := tid.String()
+	if TraceIDFormat(traceIDFormat.Load()) == TraceIDFormatW3C {
+		return s
+	}
+
+	return fmt.Sprintf("1-%s-%s", s[:8], s[8:])
+}
+
 // Log retrieves a zap logger from the context. If the optional fallback logger is provided this logger is returned
 // when the context has no logger, else a no-op logger is returned. If the context also
 // has tracing and or span information this will be logged by the logger automatically.
@@ -42,10 +70,14 @@ func Log(ctx context.Context, fbl ...*zap.Logger) *zap.Logger {
 		logs = logs.With(zap.String("span_id", span.SpanContext().SpanID().String()))
 	}
 
-	// log the trace id in the xray format, and add it as a field to the logger
+	// log the trace id in the format that matches the propagation, and add it as a field to the logger
 	if span != nil && span.SpanContext().HasTraceID() {
-		tid := span.SpanContext().TraceID().String()
-		logs = logs.With(zap.String("trace_id", fmt.Sprintf("1-%s-%s", tid[:8], tid[8:])))
+		logs = logs.With(zap.String("trace_id", FormatTraceID(span.SpanContext().TraceID())))
+	}
+
+	// if the span is recording, add the context so entries can be recorded on it
+	if span != nil && span.IsRecording() {
+		logs = logs.With(Context(ctx))
 	}
 
 	// if the lambda context is present we add a request id so logs lines follow JSON format for
@@ -34,10 +34,9 @@ func TraceHook() func(ctx context.Context, f []zap.Field) []zap.Field {
 			field = append(field, zap.String("span_id", span.SpanContext().SpanID().String()))
 		}
 
-		// log the trace id in the xray format
+		// log the trace id in the format that matches the propagation
 		if span != nil && span.SpanContext().HasTraceID() {
-			tid := span.SpanContext().TraceID().String()
-			field = append(field, zap.String("trace_id", fmt.Sprintf("1-%s-%s", tid[:8], tid[8:])))
+			field = append(field, zap.String("trace_id", FormatTraceID(span.SpanContext().TraceID())))
 		}
 
 		return field
@@ -76,4 +76,18 @@ var _ = Describe("context", func() {
 		Expect(entries.All()[0].ContextMap()).To(
 			HaveKeyWithValue("requestId", "79b4f56e-95b1-4643-9700-2807f4e68189"))
 	})
+
+	It("should log the trace id in the w3c format", func() {
+		clzap.SetTraceIDFormat(clzap.TraceIDFormatW3C)
+		DeferCleanup(clzap.SetTraceIDFormat, clzap.TraceIDFormatXRay)
+
+		ctx2 = trace.ContextWithSpanContext(ctx2, trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x01},
+			SpanID:  trace.SpanID{0x02},
+		}))
+
+		clzap.Log(ctx2).Info("foo")
+		Expect(obs.FilterMessage("foo").All()[0].ContextMap()).To(
+			HaveKeyWithValue("trace_id", "01000000000000000000000000000000"))
+	})
 })
@@ -0,0 +1,171 @@
+package clzap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelsHandlerName is the name of the http.Handler that changes the levels at runtime. It can be mounted into a
+// clwebserver server with: clwebserver.ProvideHandler(server, "/loglevels", clzap.LevelsHandlerName).
+const LevelsHandlerName = moduleName + ".levels"
+
+// Levels holds the level of the root logger, and of named loggers. The levels can be changed at runtime. The level
+// of a named logger also applies to its children, e.g: the level of "clwebserver" applies to "clwebserver.admin".
+type Levels struct {
+	root  zap.AtomicLevel
+	mu    sync.RWMutex
+	named map[string]zap.AtomicLevel
+}
+
+// NewLevels inits the levels from the configuration.
+func NewLevels(cfg Config) (*Levels, error) {
+	lvls := &Levels{root: zap.NewAtomicLevelAt(cfg.Level), named: map[string]zap.AtomicLevel{}}
+
+	for name, s := range cfg.NamedLevels {
+		lvl, err := zapcore.ParseLevel(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse level of logger '%s': %w", name, err)
+		}
+
+		lvls.named[name] = zap.NewAtomicLevelAt(lvl)
+	}
+
+	return lvls, nil
+}
+
+// Enabled returns whether any of the loggers is enabled at the level. The cores are enabled with this, such that
+// the level of each logger is decided by the core returned by WithLevels.
+func (l *Levels) Enabled(lvl zapcore.Level) bool {
+	if l.root.Enabled(lvl) {
+		return true
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, al := range l.named {
+		if al.Enabled(lvl) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Level returns the level of the named logger, an empty name returns the root level.
+func (l *Levels) Level(name string) zapcore.Level { return l.atomic(name).Level() }
+
+// SetLevel sets the level of the named logger, an empty name sets the root level.
+func (l *Levels) SetLevel(name string, lvl zapcore.Level) {
+	if name == "" {
+		l.root.SetLevel(lvl)
+
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if al, ok := l.named[name]; ok {
+		al.SetLevel(lvl)
+	} else {
+		l.named[name] = zap.NewAtomicLevelAt(lvl)
+	}
+}
+
+// UnsetLevel removes the level of the named logger, so it has the level of its parent again.
+func (l *Levels) UnsetLevel(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.named, name)
+}
+
+// atomic returns the atomic level that applies to the named logger: of the longest name that it is, or that it
+// is a child of.
+func (l *Levels) atomic(name string) zap.AtomicLevel {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	al, matched := l.root, ""
+
+	for n, nal := range l.named {
+		if (name == n || strings.HasPrefix(name, n+".")) && len(n) > len(matched) {
+			al, matched = nal, n
+		}
+	}
+
+	return al
+}
+
+// ServeHTTP lists the levels on GET. With the "logger" query parameter it gets or changes the level of that
+// logger, as zap.AtomicLevel does: PUT {"level":"debug"}. DELETE removes the level of the named logger.
+func (l *Levels) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name, named := r.URL.Query().Get("logger"), r.URL.Query().Has("logger")
+
+	switch {
+	case !named && r.Method == http.MethodGet:
+		l.mu.RLock()
+		loggers := make(map[string]zapcore.Level, len(l.named))
+
+		for n, al := range l.named {
+			loggers[n] = al.Level()
+		}
+		l.mu.RUnlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			Level   zapcore.Level            `json:"level"`
+			Loggers map[string]zapcore.Level `json:"loggers"`
+		}{l.root.Level(), loggers})
+	case !named:
+		http.Error(w, "the 'logger' query parameter is required", http.StatusBadRequest)
+	case r.Method == http.MethodDelete && name != "":
+		l.UnsetLevel(name)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && name != "":
+		// the logger gets its own level, which starts at the level it has now
+		lvl := l.Level(name)
+
+		l.mu.Lock()
+		if _, ok := l.named[name]; !ok {
+			l.named[name] = zap.NewAtomicLevelAt(lvl)
+		}
+		l.mu.Unlock()
+
+		fallthrough
+	default:
+		l.atomic(name).ServeHTTP(w, r)
+	}
+}
+
+// levelsCore only writes the entries that are enabled at the level of their logger.
+type levelsCore struct {
+	zapcore.Core
+	lvls *Levels
+}
+
+// WithLevels wraps the core so that entries are only written if they are enabled at the level of their logger.
+func WithLevels(core zapcore.Core, lvls *Levels) zapcore.Core {
+	return &levelsCore{Core: core, lvls: lvls}
+}
+
+func (c *levelsCore) Enabled(lvl zapcore.Level) bool { return c.lvls.Enabled(lvl) }
+
+func (c *levelsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelsCore{Core: c.Core.With(fields), lvls: c.lvls}
+}
+
+func (c *levelsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.lvls.atomic(ent.LoggerName).Enabled(ent.Level) {
+		return ce
+	}
+
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,95 @@
+package clzap_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var _ = Describe("levels", func() {
+	var logs *zap.Logger
+	var obs *observer.ObservedLogs
+	var lvls *clzap.Levels
+	var hdl http.Handler
+
+	BeforeEach(func(ctx context.Context) {
+		os.Setenv("CLZAP_NAMED_LEVELS", "clfoo:debug,clbar:error")
+		DeferCleanup(os.Unsetenv, "CLZAP_NAMED_LEVELS")
+
+		app := fx.New(clzap.TestProvide(), fx.Populate(&logs, &obs, &lvls,
+			fx.Annotate(&hdl, fx.ParamTags(`name:"`+clzap.LevelsHandlerName+`"`))))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should log at the level of the named logger", func() {
+		logs.Debug("root")
+		logs.Named("clfoo").Named("sub").Debug("foo")
+		logs.Named("clbar").Warn("bar")
+		logs.Named("clbarista").Info("barista")
+
+		Expect(obs.FilterMessage("root").Len()).To(Equal(0))
+		Expect(obs.FilterMessage("foo").Len()).To(Equal(1))
+		Expect(obs.FilterMessage("bar").Len()).To(Equal(0))
+		Expect(obs.FilterMessage("barista").Len()).To(Equal(1))
+	})
+
+	It("should change levels at runtime", func() {
+		lvls.SetLevel("", zapcore.DebugLevel)
+		logs.Debug("root")
+		Expect(obs.FilterMessage("root").Len()).To(Equal(1))
+
+		lvls.UnsetLevel("clfoo")
+		lvls.SetLevel("", zapcore.InfoLevel)
+		logs.Named("clfoo").Debug("foo")
+		Expect(obs.FilterMessage("foo").Len()).To(Equal(0))
+	})
+
+	It("should serve and change levels", func() {
+		rec := httptest.NewRecorder()
+		hdl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		var all struct {
+			Level   string            `json:"level"`
+			Loggers map[string]string `json:"loggers"`
+		}
+		Expect(json.NewDecoder(rec.Body).Decode(&all)).To(Succeed())
+		Expect(all.Level).To(Equal("info"))
+		Expect(all.Loggers).To(Equal(map[string]string{"clfoo": "debug", "clbar": "error"}))
+
+		rec = httptest.NewRecorder()
+		hdl.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/?logger=clbaz.sub", strings.NewReader(`{"level":"debug"}`)))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(lvls.Level("clbaz.sub")).To(Equal(zapcore.DebugLevel))
+		Expect(lvls.Level("clbaz")).To(Equal(zapcore.InfoLevel))
+
+		logs.Named("clbaz").Named("sub").Debug("baz")
+		Expect(obs.FilterMessage("baz").Len()).To(Equal(1))
+
+		rec = httptest.NewRecorder()
+		hdl.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?logger=clbar", nil))
+		Expect(rec.Body.String()).To(MatchJSON(`{"level":"error"}`))
+
+		rec = httptest.NewRecorder()
+		hdl.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/?logger=clbar", nil))
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+		Expect(lvls.Level("clbar")).To(Equal(zapcore.InfoLevel))
+	})
+
+	It("should not parse invalid levels", func() {
+		_, err := clzap.NewLevels(clzap.Config{NamedLevels: map[string]string{"clfoo": "loud"}})
+		Expect(err).To(MatchError(ContainSubstring("failed to parse level of logger 'clfoo'")))
+	})
+})
@@ -126,3 +126,35 @@ var _ = Describe("second core", func() {
 		logs.Info("log something for secondary core")
 	})
 })
+
+var _ = Describe("secondary cores group", func() {
+	var logs *zap.Logger
+	var buf1, buf2 *bytes.Buffer
+
+	BeforeEach(func(ctx context.Context) {
+		buf1, buf2 = bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		newCore := func(buf *bytes.Buffer, name string) func() *clzap.SecondaryCore {
+			return func() *clzap.SecondaryCore {
+				return &clzap.SecondaryCore{Name: name, Core: zapcore.NewCore(
+					zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(buf), zapcore.DebugLevel)}
+			}
+		}
+
+		app := fx.New(clzap.TestProvide(), fx.Populate(&logs),
+			clzap.ProvideSecondaryCore(newCore(buf1, "core1")),
+			clzap.ProvideSecondaryCore(newCore(buf2, "core2")),
+			clzap.ProvideSecondaryCore(func() *clzap.SecondaryCore { return nil }))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should write to all cores", func() {
+		logs.Info("log something for all cores")
+
+		Expect(buf1.String()).To(ContainSubstring("log something for all cores"))
+		Expect(buf2.String()).To(ContainSubstring("log something for all cores"))
+		Expect(buf1.String()).To(Or( // value groups have no order
+			ContainSubstring(`"secondary_core_names":["core1","core2"]`),
+			ContainSubstring(`"secondary_core_names":["core2","core1"]`)))
+	})
+})
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/crewlinker/clgo/clconfig"
 	"github.com/onsi/ginkgo/v2"
@@ -30,6 +32,19 @@ type Config struct {
 	ConsoleEncoding bool `env:"CONSOLE_ENCODING"`
 	// DevelopmentEncodingConfig enables encoding useful for developers.
 	DevelopmentEncodingConfig bool `env:"DEVELOPMENT_ENCODING_CONFIG"`
+	// SpanEventLevel configures the level at which log entries are also recorded as events on the span in the
+	// context, and mark the span as failed.
+	SpanEventLevel zapcore.Level `env:"SPAN_EVENT_LEVEL" envDefault:"error"`
+	// NamedLevels configures the levels of named loggers (and their children), e.g: "clotel:debug,fx:warn". The
+	// levels can be changed at runtime with the handler named LevelsHandlerName.
+	NamedLevels map[string]string `env:"NAMED_LEVELS"`
+	// Sampling configures the sampling of named loggers (and their children) as "first/thereafter" per tick, e.g:
+	// "clwebserver:100/10". The "*" name configures the sampling of all other loggers.
+	Sampling map[string]string `env:"SAMPLING"`
+	// SamplingTick configures the interval at which the sampling counts are reset.
+	SamplingTick time.Duration `env:"SAMPLING_TICK" envDefault:"1s"`
+	// RedactKeys configures the (case-insensitive) keys of fields, nested object fields, headers and map entries whose values are masked.
+	RedactKeys []string `env:"REDACT_KEYS" envDefault:"authorization,cookie,set-cookie,password,refresh_token"`
 }
 
 // Fx is a convenient option that configures fx to use the zap logger.
@@ -49,23 +64,55 @@ type SecondaryCore = struct {
 	Name string
 }
 
-// newLogger can be used to create a logger from a single core or from two cores: writing to both.
-func newLogger(zc zapcore.Core, sc *SecondaryCore, cfg Config) *zap.Logger {
+// secondaryCoresGroup is the fx value group for providing any number of secondary cores.
+const secondaryCoresGroup = moduleName + ".secondary_cores"
+
+// ProvideSecondaryCore provides a secondary core through a value group, so multiple components can each make the
+// logger write to their own core. The constructor must return a *SecondaryCore, which may be nil.
+func ProvideSecondaryCore(constructor any) fx.Option {
+	return fx.Provide(fx.Annotate(constructor, fx.ResultTags(`group:"`+secondaryCoresGroup+`"`)))
+}
+
+// newLogger can be used to create a logger from a single core or from multiple cores: writing to all. The entries
+// are redacted, filtered by the level of their logger and sampled before they are written.
+func newLogger(
+	zc zapcore.Core, sc *SecondaryCore, cfg Config, scs []*SecondaryCore, lvls *Levels,
+) (*zap.Logger, error) {
 	opts := []zap.Option{}
 
 	if cfg.DevelopmentEncodingConfig {
 		opts = append(opts, zap.Development())
 	}
 
-	l := zap.New(zc, opts...)
-	if sc == nil {
-		return l
-	} else {
-		l := zap.New(zapcore.NewTee(zc, sc.Core))
-		l.Info("logger initialized with secondary core", zap.String("second_core_name", sc.Name))
+	cores, names := []zapcore.Core{zc}, []string{}
 
-		return l
+	for _, sc := range append([]*SecondaryCore{sc}, scs...) {
+		if sc != nil {
+			cores, names = append(cores, sc.Core), append(names, sc.Name)
+		}
 	}
+
+	core, err := WithSampling(WithSpanEvents(zapcore.NewTee(cores...), cfg.SpanEventLevel), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	l := zap.New(WithRedaction(WithLevels(core, lvls), cfg.RedactKeys), opts...)
+	if len(names) > 0 {
+		l.Info("logger initialized with secondary cores", zap.Strings("secondary_core_names", names))
+	}
+
+	return l, nil
+}
+
+// provideLevels provides the levels, the level enabler for the cores and the handler that changes the levels.
+func provideLevels() fx.Option {
+	return fx.Options(
+		fx.Provide(NewLevels),
+		fx.Provide(func(l *Levels) zapcore.LevelEnabler { return l }),
+		fx.Provide(fx.Annotate(func(l *Levels) http.Handler { return l },
+			fx.ResultTags(`name:"`+LevelsHandlerName+`"`))),
+	)
 }
 
 // newEncoder constructs the encoder based on the encoder config and our env config.
@@ -95,11 +142,11 @@ func Provide() fx.Option {
 	return fx.Module(moduleName,
 		// provide the environment configuration
 		clconfig.Provide[Config](strings.ToUpper(moduleName)+"_"),
-		// allow environmental config to configure the level at which to log
-		fx.Provide(func(cfg Config) zapcore.LevelEnabler { return cfg.Level }),
+		// allow environmental config to configure the levels at which to log, they can be changed at runtime
+		provideLevels(),
 		// provide the zapper, make sure everything is synced on shutdown
 		fx.Provide(fx.Annotate(newLogger,
-			fx.ParamTags(``, `optional:"true"`),
+			fx.ParamTags(``, `optional:"true"`, ``, `group:"`+secondaryCoresGroup+`"`, ``),
 			fx.OnStop(func(ctx context.Context, l *zap.Logger) error {
 				_ = l.Sync() // ignore to support TTY: https://github.com/uber-go/zap/issues/880
 
@@ -163,10 +210,10 @@ func Observed() fx.Option {
 	return fx.Module(moduleName+"-observed",
 		// provide the environment configuration
 		clconfig.Provide[Config](strings.ToUpper(moduleName)+"_"),
-		fx.Provide(func(cfg Config) zapcore.LevelEnabler { return cfg.Level }),
+		provideLevels(),
 		fx.Provide(newObservedAndConsole),
 		fx.Provide(fx.Annotate(newLogger,
-			fx.ParamTags(``, `optional:"true"`),
+			fx.ParamTags(``, `optional:"true"`, ``, `group:"`+secondaryCoresGroup+`"`, ``),
 			fx.OnStop(func(ctx context.Context, l *zap.Logger) error {
 				if err := l.Sync(); err != nil {
 					return fmt.Errorf("failed to sync: %w", err)
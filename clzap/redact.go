@@ -0,0 +1,385 @@
+package clzap
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces the values that are redacted.
+const Redacted = "[REDACTED]"
+
+// redactCore masks the values of fields with the configured keys.
+type redactCore struct {
+	zapcore.Core
+	keys map[string]bool
+}
+
+// WithRedaction wraps the core so that the values of fields with the keys (case-insensitive) are masked. The
+// values in header and string maps are also masked, e.g. the Authorization header of a logged http.Header. Fields
+// of (nested) zap.Object, zap.Inline and zap.Array values are masked as they are encoded. Values that are encoded
+// through reflection, e.g. a plain struct logged with zap.Reflect or zap.Any, are NOT inspected.
+func WithRedaction(core zapcore.Core, keys []string) zapcore.Core {
+	if len(keys) == 0 {
+		return core
+	}
+
+	c := &redactCore{Core: core, keys: make(map[string]bool, len(keys))}
+	for _, k := range keys {
+		c.keys[strings.ToLower(k)] = true
+	}
+
+	return c
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redact(fields)), keys: c.keys}
+}
+
+// Check lets the underlying core check the entry, and writes the checked entry with the redacted fields.
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if checked := c.Core.Check(ent, nil); checked != nil {
+		return ce.AddCore(ent, redactWriter{c: c, checked: checked})
+	}
+
+	return ce
+}
+
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.redact(fields)) //nolint:wrapcheck
+}
+
+// redact returns the fields with the values masked, the fields are only copied if any are redacted.
+func (c *redactCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+
+	for i, f := range fields {
+		rf, ok := c.redactField(f)
+		if !ok {
+			continue
+		}
+
+		if redacted == nil {
+			redacted = append(make([]zapcore.Field, 0, len(fields)), fields...)
+		}
+
+		redacted[i] = rf
+	}
+
+	if redacted == nil {
+		return fields
+	}
+
+	return redacted
+}
+
+// redactField returns the redacted field, and false if nothing was redacted.
+func (c *redactCore) redactField(f zapcore.Field) (zapcore.Field, bool) {
+	if f.Type == zapcore.SkipType {
+		return f, false
+	}
+
+	if c.keys[strings.ToLower(f.Key)] {
+		return zap.String(f.Key, Redacted), true
+	}
+
+	// marshalers are wrapped so their fields are masked as they are encoded, the field itself stays the same.
+	switch v := f.Interface.(type) {
+	case zapcore.ObjectMarshaler:
+		if f.Type == zapcore.ObjectMarshalerType || f.Type == zapcore.InlineMarshalerType {
+			f.Interface = redactObject{keys: c.keys, obj: v}
+
+			return f, true
+		}
+	case zapcore.ArrayMarshaler:
+		if f.Type == zapcore.ArrayMarshalerType {
+			f.Interface = redactArray{keys: c.keys, arr: v}
+
+			return f, true
+		}
+	}
+
+	if v, ok := redactValue(c.keys, f.Interface); ok {
+		return zap.Any(f.Key, v), true
+	}
+
+	return f, false
+}
+
+// redactValue returns a copy of header and string maps with the values of the keys replaced, and false if
+// nothing was redacted.
+func redactValue(keys map[string]bool, val any) (any, bool) {
+	switch v := val.(type) {
+	case http.Header:
+		if containsKey(keys, v) {
+			return http.Header(redactMap(keys, v, []string{Redacted})), true
+		}
+	case map[string][]string:
+		if containsKey(keys, v) {
+			return redactMap(keys, v, []string{Redacted}), true
+		}
+	case map[string]string:
+		if containsKey(keys, v) {
+			return redactMap(keys, v, Redacted), true
+		}
+	}
+
+	return val, false
+}
+
+// containsKey returns whether the map has any of the keys.
+func containsKey[V any](keys map[string]bool, m map[string]V) bool {
+	for k := range m {
+		if keys[strings.ToLower(k)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactMap returns a copy of the map with the values of the keys replaced.
+func redactMap[V any](keys map[string]bool, m map[string]V, redacted V) map[string]V {
+	cp := make(map[string]V, len(m))
+	for k, v := range m {
+		if keys[strings.ToLower(k)] {
+			v = redacted
+		}
+
+		cp[k] = v
+	}
+
+	return cp
+}
+
+// redactObject marshals the object with an encoder that masks the values of the keys.
+type redactObject struct {
+	keys map[string]bool
+	obj  zapcore.ObjectMarshaler
+}
+
+func (o redactObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return o.obj.MarshalLogObject(redactEncoder{ObjectEncoder: enc, keys: o.keys}) //nolint:wrapcheck
+}
+
+// redactArray marshals the array with an encoder that masks the values of the keys in its objects.
+type redactArray struct {
+	keys map[string]bool
+	arr  zapcore.ArrayMarshaler
+}
+
+func (a redactArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	return a.arr.MarshalLogArray(redactArrayEncoder{ArrayEncoder: enc, keys: a.keys}) //nolint:wrapcheck
+}
+
+// redactArrayEncoder wraps the objects and arrays that are appended, so their fields are masked.
+type redactArrayEncoder struct {
+	zapcore.ArrayEncoder
+	keys map[string]bool
+}
+
+func (e redactArrayEncoder) AppendObject(v zapcore.ObjectMarshaler) error {
+	return e.ArrayEncoder.AppendObject(redactObject{keys: e.keys, obj: v}) //nolint:wrapcheck
+}
+
+func (e redactArrayEncoder) AppendArray(v zapcore.ArrayMarshaler) error {
+	return e.ArrayEncoder.AppendArray(redactArray{keys: e.keys, arr: v}) //nolint:wrapcheck
+}
+
+func (e redactArrayEncoder) AppendReflected(v any) error {
+	v, _ = redactValue(e.keys, v)
+
+	return e.ArrayEncoder.AppendReflected(v) //nolint:wrapcheck
+}
+
+// redactEncoder masks the values of the keys as an object is encoded.
+type redactEncoder struct {
+	zapcore.ObjectEncoder
+	keys map[string]bool
+}
+
+// masked adds the redacted value if the key is masked, and reports whether it did.
+func (e redactEncoder) masked(key string) bool {
+	if !e.keys[strings.ToLower(key)] {
+		return false
+	}
+
+	e.ObjectEncoder.AddString(key, Redacted)
+
+	return true
+}
+
+func (e redactEncoder) AddArray(k string, v zapcore.ArrayMarshaler) error {
+	if e.masked(k) {
+		return nil
+	}
+
+	return e.ObjectEncoder.AddArray(k, redactArray{keys: e.keys, arr: v}) //nolint:wrapcheck
+}
+
+func (e redactEncoder) AddObject(k string, v zapcore.ObjectMarshaler) error {
+	if e.masked(k) {
+		return nil
+	}
+
+	return e.ObjectEncoder.AddObject(k, redactObject{keys: e.keys, obj: v}) //nolint:wrapcheck
+}
+
+func (e redactEncoder) AddReflected(k string, v any) error {
+	if e.masked(k) {
+		return nil
+	}
+
+	v, _ = redactValue(e.keys, v)
+
+	return e.ObjectEncoder.AddReflected(k, v) //nolint:wrapcheck
+}
+
+func (e redactEncoder) AddBinary(k string, v []byte) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddBinary(k, v)
+	}
+}
+
+func (e redactEncoder) AddByteString(k string, v []byte) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddByteString(k, v)
+	}
+}
+
+func (e redactEncoder) AddBool(k string, v bool) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddBool(k, v)
+	}
+}
+
+func (e redactEncoder) AddComplex128(k string, v complex128) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddComplex128(k, v)
+	}
+}
+
+func (e redactEncoder) AddComplex64(k string, v complex64) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddComplex64(k, v)
+	}
+}
+
+func (e redactEncoder) AddDuration(k string, v time.Duration) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddDuration(k, v)
+	}
+}
+
+func (e redactEncoder) AddFloat64(k string, v float64) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddFloat64(k, v)
+	}
+}
+
+func (e redactEncoder) AddFloat32(k string, v float32) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddFloat32(k, v)
+	}
+}
+
+func (e redactEncoder) AddInt(k string, v int) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddInt(k, v)
+	}
+}
+
+func (e redactEncoder) AddInt64(k string, v int64) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddInt64(k, v)
+	}
+}
+
+func (e redactEncoder) AddInt32(k string, v int32) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddInt32(k, v)
+	}
+}
+
+func (e redactEncoder) AddInt16(k string, v int16) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddInt16(k, v)
+	}
+}
+
+func (e redactEncoder) AddInt8(k string, v int8) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddInt8(k, v)
+	}
+}
+
+func (e redactEncoder) AddString(k, v string) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddString(k, v)
+	}
+}
+
+func (e redactEncoder) AddTime(k string, v time.Time) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddTime(k, v)
+	}
+}
+
+func (e redactEncoder) AddUint(k string, v uint) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddUint(k, v)
+	}
+}
+
+func (e redactEncoder) AddUint64(k string, v uint64) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddUint64(k, v)
+	}
+}
+
+func (e redactEncoder) AddUint32(k string, v uint32) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddUint32(k, v)
+	}
+}
+
+func (e redactEncoder) AddUint16(k string, v uint16) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddUint16(k, v)
+	}
+}
+
+func (e redactEncoder) AddUint8(k string, v uint8) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddUint8(k, v)
+	}
+}
+
+func (e redactEncoder) AddUintptr(k string, v uintptr) {
+	if !e.masked(k) {
+		e.ObjectEncoder.AddUintptr(k, v)
+	}
+}
+
+// redactWriter writes the entry that was checked by the underlying core, with the fields redacted.
+type redactWriter struct {
+	c       *redactCore
+	checked *zapcore.CheckedEntry
+}
+
+func (w redactWriter) Enabled(zapcore.Level) bool        { return true }
+func (w redactWriter) With([]zapcore.Field) zapcore.Core { return w }
+func (w redactWriter) Sync() error                       { return nil }
+
+func (w redactWriter) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(ent, w)
+}
+
+func (w redactWriter) Write(_ zapcore.Entry, fields []zapcore.Field) error {
+	w.checked.Write(w.c.redact(fields)...)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package clzap_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var _ = Describe("redaction", func() {
+	var logs *zap.Logger
+	var obs *observer.ObservedLogs
+
+	BeforeEach(func(ctx context.Context) {
+		app := fx.New(clzap.TestProvide(), fx.Populate(&logs, &obs))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should redact fields, headers and maps", func() {
+		hdr := http.Header{"Authorization": {"Bearer abc"}, "Accept": {"*/*"}}
+
+		logs.With(zap.String("Password", "secret")).Info("foo",
+			zap.String("refresh_token", "rt"),
+			zap.Any("header", hdr),
+			zap.Any("form", map[string]string{"cookie": "c", "name": "bob"}),
+			zap.String("user", "bob"))
+
+		entries := obs.FilterMessage("foo").All()
+		Expect(entries).To(HaveLen(1))
+
+		fields := entries[0].ContextMap()
+		Expect(fields).To(HaveKeyWithValue("Password", clzap.Redacted))
+		Expect(fields).To(HaveKeyWithValue("refresh_token", clzap.Redacted))
+		Expect(fields).To(HaveKeyWithValue("header", http.Header{"Authorization": {clzap.Redacted}, "Accept": {"*/*"}}))
+		Expect(fields).To(HaveKeyWithValue("form", map[string]string{"cookie": clzap.Redacted, "name": "bob"}))
+		Expect(fields).To(HaveKeyWithValue("user", "bob"))
+
+		Expect(hdr.Get("Authorization")).To(Equal("Bearer abc")) // the logged value is not changed
+	})
+
+	It("should redact nested objects and arrays", func() {
+		creds := zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("user", "bob")
+			enc.AddString("password", "secret")
+
+			return enc.AddObject("session", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				enc.AddInt("refresh_token", 42)
+
+				return enc.AddReflected("cookies", map[string]string{"cookie": "c", "lang": "en"})
+			}))
+		})
+
+		logs.Info("foo",
+			zap.Object("creds", creds),
+			zap.Array("all", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+				return enc.AppendObject(creds)
+			})),
+			zap.Inline(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+				enc.AddString("authorization", "Bearer abc")
+
+				return nil
+			})))
+
+		want := map[string]any{
+			"user": "bob", "password": clzap.Redacted, "session": map[string]any{
+				"refresh_token": clzap.Redacted,
+				"cookies":       map[string]string{"cookie": clzap.Redacted, "lang": "en"},
+			},
+		}
+
+		fields := obs.FilterMessage("foo").All()[0].ContextMap()
+		Expect(fields).To(HaveKeyWithValue("creds", want))
+		Expect(fields).To(HaveKeyWithValue("all", []any{want}))
+		Expect(fields).To(HaveKeyWithValue("authorization", clzap.Redacted))
+	})
+
+	It("should not redact values that are encoded through reflection", func() {
+		type creds struct{ Password string }
+
+		logs.Info("foo", zap.Reflect("creds", creds{Password: "secret"}))
+		Expect(obs.FilterMessage("foo").All()[0].ContextMap()).To(
+			HaveKeyWithValue("creds", creds{Password: "secret"}))
+	})
+
+	It("should redact with configured keys", func() {
+		os.Setenv("CLZAP_REDACT_KEYS", "ssn")
+		DeferCleanup(os.Unsetenv, "CLZAP_REDACT_KEYS")
+
+		var logs2 *zap.Logger
+		var obs2 *observer.ObservedLogs
+		Expect(fx.New(clzap.TestProvide(), fx.Populate(&logs2, &obs2)).Err()).To(Succeed())
+
+		logs2.Info("foo", zap.String("ssn", "123"), zap.String("password", "p"))
+		Expect(obs2.FilterMessage("foo").All()[0].ContextMap()).To(Equal(map[string]any{
+			"ssn": clzap.Redacted, "password": "p",
+		}))
+	})
+
+	It("should not change the core without keys", func() {
+		core, _ := observer.New(zapcore.InfoLevel)
+		Expect(clzap.WithRedaction(core, nil)).To(BeIdenticalTo(core))
+	})
+})
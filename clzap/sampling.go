@@ -0,0 +1,66 @@
+package clzap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// samplingCore samples the entries of named loggers, each with their own sampler.
+type samplingCore struct {
+	zapcore.Core
+	samplers map[string]zapcore.Core
+}
+
+// WithSampling wraps the core so the entries of the configured loggers are sampled: per tick, the first N entries
+// with the same level and message are written, and thereafter only every Mth entry. The sampling of a named logger
+// also applies to its children, and "*" configures the sampling of all other loggers.
+func WithSampling(core zapcore.Core, cfg Config) (zapcore.Core, error) {
+	if len(cfg.Sampling) == 0 {
+		return core, nil
+	}
+
+	samplers := make(map[string]zapcore.Core, len(cfg.Sampling))
+
+	for name, s := range cfg.Sampling {
+		first, thereafter, ok := strings.Cut(s, "/")
+		firstN, ferr := strconv.Atoi(first)
+		thereafterN, terr := strconv.Atoi(thereafter)
+
+		if !ok || ferr != nil || terr != nil {
+			return nil, fmt.Errorf("invalid sampling of logger '%s', expected 'first/thereafter': '%s'", name, s) //nolint:goerr113
+		}
+
+		samplers[name] = zapcore.NewSamplerWithOptions(core, cfg.SamplingTick, firstN, thereafterN)
+	}
+
+	return &samplingCore{Core: core, samplers: samplers}, nil
+}
+
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	samplers := make(map[string]zapcore.Core, len(c.samplers))
+	for name, smp := range c.samplers {
+		samplers[name] = smp.With(fields) // the sampler's counts are shared with its clone
+	}
+
+	return &samplingCore{Core: c.Core.With(fields), samplers: samplers}
+}
+
+// Check lets the sampler of the logger check the entry, or the underlying core if it is not sampled.
+func (c *samplingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	smp, matched := c.samplers["*"], ""
+
+	for name, nsmp := range c.samplers {
+		if (ent.LoggerName == name || strings.HasPrefix(ent.LoggerName, name+".")) && len(name) > len(matched) {
+			smp, matched = nsmp, name
+		}
+	}
+
+	if smp == nil {
+		return c.Core.Check(ent, ce)
+	}
+
+	return smp.Check(ent, ce)
+}
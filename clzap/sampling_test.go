@@ -0,0 +1,50 @@
+package clzap_test
+
+import (
+	"context"
+	"os"
+
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var _ = Describe("sampling", func() {
+	var logs *zap.Logger
+	var obs *observer.ObservedLogs
+
+	BeforeEach(func(ctx context.Context) {
+		os.Setenv("CLZAP_SAMPLING", "clfoo:2/5,*:10/0")
+		DeferCleanup(os.Unsetenv, "CLZAP_SAMPLING")
+
+		app := fx.New(clzap.TestProvide(), fx.Populate(&logs, &obs))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should sample per logger", func() {
+		foo := logs.Named("clfoo").With(zap.String("bar", "baz"))
+		for range 12 {
+			foo.Info("foo")
+			logs.Named("clbar").Info("bar")
+		}
+
+		Expect(obs.FilterMessage("foo").Len()).To(Equal(4)) // the first 2, then every 5th: the 7th and 12th
+		Expect(obs.FilterMessage("bar").Len()).To(Equal(10))
+	})
+
+	It("should not sample without configuration", func() {
+		core, _ := observer.New(zapcore.InfoLevel)
+		Expect(clzap.WithSampling(core, clzap.Config{})).To(BeIdenticalTo(core))
+	})
+
+	It("should reject invalid sampling", func() {
+		core, _ := observer.New(zapcore.InfoLevel)
+		_, err := clzap.WithSampling(core, clzap.Config{Sampling: map[string]string{"clfoo": "2"}})
+		Expect(err).To(MatchError(ContainSubstring("invalid sampling of logger 'clfoo'")))
+	})
+})
@@ -0,0 +1,140 @@
+package clzap
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Context returns a field that carries the context, it is not encoded. It allows cores to link the log entry to
+// the span in the context, such as the core returned by WithSpanEvents, and the OpenTelemetry zap bridge.
+func Context(ctx context.Context) zap.Field {
+	return zap.Field{Key: "context", Type: zapcore.SkipType, Interface: ctx}
+}
+
+// handled is the type of the field that marks an entry as handled.
+type handled struct{}
+
+// Handled returns a field that marks the entry as handled elsewhere, it is not encoded. E.g. the error of the entry
+// is returned to, and reported by, an interceptor. Cores that report entries, such as to an error tracker, should
+// skip entries with it.
+func Handled() zap.Field {
+	return zap.Field{Key: "handled", Type: zapcore.SkipType, Interface: handled{}}
+}
+
+// IsHandled reports whether the fields include the Handled field.
+func IsHandled(fields []zapcore.Field) bool {
+	return slices.ContainsFunc(fields, func(f zapcore.Field) bool {
+		_, ok := f.Interface.(handled)
+
+		return ok && f.Type == zapcore.SkipType
+	})
+}
+
+// spanFromFields returns the span of the last context field, if any.
+func spanFromFields(fields []zapcore.Field) (span trace.Span) {
+	for _, f := range fields {
+		if ctx, ok := f.Interface.(context.Context); ok && f.Type == zapcore.SkipType {
+			span = trace.SpanFromContext(ctx)
+		}
+	}
+
+	return span
+}
+
+// spanEventCore records log entries as events on the span in the context, and marks the span as failed.
+type spanEventCore struct {
+	zapcore.Core
+	lvl  zapcore.LevelEnabler
+	span trace.Span
+}
+
+// WithSpanEvents wraps the core so that entries of the level are also recorded as events on the span in the
+// context, and the status of the span is set to error. The context is added to entries with the Context field,
+// which Log does.
+func WithSpanEvents(core zapcore.Core, lvl zapcore.LevelEnabler) zapcore.Core {
+	return &spanEventCore{Core: core, lvl: lvl}
+}
+
+// With adds the fields to the underlying core, and remembers the span if a context is added.
+func (c *spanEventCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &spanEventCore{Core: c.Core.With(fields), lvl: c.lvl, span: c.span}
+	if span := spanFromFields(fields); span != nil {
+		clone.span = span
+	}
+
+	return clone
+}
+
+// Check lets the underlying core decide if it writes the entry, and adds the recording on the span.
+func (c *spanEventCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	ce = c.Core.Check(ent, ce)
+	if c.lvl.Enabled(ent.Level) {
+		ce = ce.AddCore(ent, spanEventRecorder{span: c.span})
+	}
+
+	return ce
+}
+
+// Write writes the entry to the underlying core, and records it on the span. It is only called directly when the
+// core is teed, else the underlying core is written to because it was checked.
+func (c *spanEventCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if err := c.Core.Write(ent, fields); err != nil {
+		return fmt.Errorf("failed to write underlying: %w", err)
+	}
+
+	if c.lvl.Enabled(ent.Level) {
+		return spanEventRecorder{span: c.span}.Write(ent, fields)
+	}
+
+	return nil
+}
+
+// spanEventRecorder is the core that records a checked entry on the span.
+type spanEventRecorder struct{ span trace.Span }
+
+func (r spanEventRecorder) Enabled(zapcore.Level) bool        { return true }
+func (r spanEventRecorder) With([]zapcore.Field) zapcore.Core { return r }
+func (r spanEventRecorder) Sync() error                       { return nil }
+
+func (r spanEventRecorder) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(ent, r)
+}
+
+// Write records the entry as an event on the span, with the fields as attributes.
+func (r spanEventRecorder) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	span := r.span
+	if fspan := spanFromFields(fields); fspan != nil {
+		span = fspan
+	}
+
+	if span == nil || !span.IsRecording() {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(enc.Fields)+2) //nolint:mnd
+	attrs = append(attrs,
+		attribute.String("log.severity", ent.Level.CapitalString()),
+		attribute.String("log.message", ent.Message))
+
+	for _, k := range slices.Sorted(maps.Keys(enc.Fields)) {
+		attrs = append(attrs, attribute.String(k, fmt.Sprint(enc.Fields[k])))
+	}
+
+	span.AddEvent("log", trace.WithAttributes(attrs...), trace.WithTimestamp(ent.Time))
+	span.SetStatus(codes.Error, ent.Message)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package clzap_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/crewlinker/clgo/clzap"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var _ = Describe("span events", func() {
+	var logs *zap.Logger
+	var obs *observer.ObservedLogs
+	var rec *tracetest.SpanRecorder
+	var trp *sdktrace.TracerProvider
+
+	BeforeEach(func(ctx context.Context) {
+		rec = tracetest.NewSpanRecorder()
+		trp = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+
+		app := fx.New(clzap.TestProvide(), fx.Populate(&logs, &obs))
+		Expect(app.Start(ctx)).To(Succeed())
+		DeferCleanup(app.Stop)
+	})
+
+	It("should record error entries on the span", func(ctx context.Context) {
+		ctx, span := trp.Tracer("test").Start(ctx, "my-span")
+		clzap.Log(ctx, logs).Info("fine")
+		clzap.Log(ctx, logs).Error("broken", zap.Error(errors.New("boom")))
+		span.End()
+
+		Expect(obs.FilterMessage("broken").Len()).To(Equal(1))
+		Expect(obs.FilterMessage("broken").All()[0].ContextMap()).ToNot(HaveKey("context"))
+
+		spans := rec.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		Expect(spans[0].Status().Description).To(Equal("broken"))
+		Expect(spans[0].Events()).To(HaveLen(1))
+		Expect(spans[0].Events()[0].Attributes).To(Equal([]attribute.KeyValue{
+			attribute.String("log.severity", "ERROR"),
+			attribute.String("log.message", "broken"),
+			attribute.String("error", "boom"),
+		}))
+	})
+
+	It("should record with the context field", func(ctx context.Context) {
+		ctx, span := trp.Tracer("test").Start(ctx, "my-span")
+		logs.Error("broken", clzap.Context(ctx))
+		span.End()
+
+		Expect(rec.Ended()[0].Events()).To(HaveLen(1))
+	})
+
+	It("should not record without a span", func(ctx context.Context) {
+		clzap.Log(ctx, logs).Error("broken")
+		Expect(obs.FilterMessage("broken").Len()).To(Equal(1))
+	})
+
+	It("should mark entries as handled, without encoding it", func(ctx context.Context) {
+		logs.Error("broken", clzap.Handled())
+
+		entries := obs.FilterMessage("broken").All()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].ContextMap()).To(BeEmpty())
+		Expect(clzap.IsHandled(entries[0].Context)).To(BeTrue())
+		Expect(clzap.IsHandled([]zap.Field{clzap.Context(ctx)})).To(BeFalse())
+	})
+})